@@ -0,0 +1,209 @@
+package streams
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+/** This file provides package level terminal functions that aggregate the elements of a stream into a single value **/
+
+// MinMax finds the smallest and the largest elements of the stream in a single pass. Returns `ok=false` if the stream
+// produced no elements.
+func MinMax[T ISortable](s IStream[T]) (min T, max T, ok bool) {
+	s.ForEach(func(x T) {
+		if !ok {
+			min, max, ok = x, x, true
+			return
+		}
+		if x < min {
+			min = x
+		}
+		if x > max {
+			max = x
+		}
+	})
+	return
+}
+
+// SummaryStats calculates the count, sum, min, max and average of the elements of the stream in a single pass, useful
+// when several aggregates are needed without processing the stream more than once. The sum is calculated with the type
+// of the elements, so it may overflow for small integer types.
+func SummaryStats[T INumber](s IStream[T]) (ret Stats[T]) {
+	s.ForEach(func(x T) {
+		if ret.Count == 0 {
+			ret.Min, ret.Max = x, x
+		}
+		if x < ret.Min {
+			ret.Min = x
+		}
+		if x > ret.Max {
+			ret.Max = x
+		}
+		ret.Sum += x
+		ret.Count++
+	})
+	if ret.Count > 0 {
+		ret.Average = float64(ret.Sum) / float64(ret.Count)
+	}
+	return
+}
+
+// CountDistinctBy counts the unique keys produced by `keyFn` for the elements of the stream, such as the amount of
+// different countries in a stream of people, without grouping the elements.
+func CountDistinctBy[T comparable, K comparable](s IStream[T], keyFn func(T) K) int {
+	seen := map[K]struct{}{}
+	s.ForEach(func(x T) {
+		seen[keyFn(x)] = struct{}{}
+	})
+	return len(seen)
+}
+
+// TopKFrequent finds the `k` most frequent elements of the stream, in descending order of frequency, such as the most
+// used tags of a collection of posts. Elements with the same frequency are kept in the order they were first found.
+// Returns fewer elements if the stream contains less than `k` unique elements.
+func TopKFrequent[T comparable](s IStream[T], k int) IList[T] {
+	if k <= 0 {
+		return NewList[T]()
+	}
+
+	counts, order := countOccurrences[T](s)
+	sort.SliceStable(order, func(i, j int) bool {
+		return counts[order[i]] > counts[order[j]]
+	})
+
+	if k < len(order) {
+		order = order[:k:k]
+	}
+	return NewList[T](order)
+}
+
+// FrequencyRanked counts the occurrences of every unique element of the stream, returning the elements paired with their
+// counts in descending order of frequency, a common shape for reports. Elements with the same frequency are sorted by
+// their natural order if their type has one (such as strings or numbers), otherwise they are kept in the order they
+// were first found.
+func FrequencyRanked[T comparable](s IStream[T]) IList[*KeyValuePair[T, int]] {
+	counts, order := countOccurrences[T](s)
+	natural := hasNaturalOrder[T]()
+
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		if counts[a] != counts[b] {
+			return counts[a] > counts[b]
+		}
+		return natural && naturalCompare[T](a, b) < 0
+	})
+
+	ret := NewListCap[*KeyValuePair[T, int]](len(order))
+	for _, x := range order {
+		_ = ret.Add(NewPair(x, counts[x]))
+	}
+	return ret
+}
+
+// countOccurrences counts the occurrences of every unique element of the stream, also returning the unique elements in
+// the order they were first found
+func countOccurrences[T comparable](s IStream[T]) (counts map[T]int, order []T) {
+	counts = map[T]int{}
+	s.ForEach(func(x T) {
+		if _, ok := counts[x]; !ok {
+			order = append(order, x)
+		}
+		counts[x]++
+	})
+	return
+}
+
+// FoldLeft accumulates the elements of the stream from the first to the last, starting with the provided identity.
+//
+//	Eg:   FoldLeft(["a", "b", "c"], "", concat)   ->   concat(concat(concat("", "a"), "b"), "c")
+func FoldLeft[T comparable, R any](s IStream[T], identity R, f func(R, T) R) R {
+	ret := identity
+	s.ForEach(func(x T) {
+		ret = f(ret, x)
+	})
+	return ret
+}
+
+// FoldRight accumulates the elements of the stream from the last to the first, starting with the provided identity.
+// Since the last element has to be known before accumulating, all the elements of the stream are buffered before the
+// accumulation starts, which for lazy sources of unknown length (such as channels) means blocking until the source is
+// exhausted.
+//
+//	Eg:   FoldRight(["a", "b", "c"], "", concat)   ->   concat("a", concat("b", concat("c", "")))
+func FoldRight[T comparable, R any](s IStream[T], identity R, f func(T, R) R) R {
+	arr := s.ToArray()
+	ret := identity
+	for i := len(arr) - 1; i >= 0; i-- {
+		ret = f(arr[i], ret)
+	}
+	return ret
+}
+
+// ParallelReduce reduces the elements of the stream by splitting the result of the stream into segments which are
+// reduced concurrently, and then combining the results of every segment in order. The reduction must be associative,
+// since the accumulation of every segment starts from the identity value.
+//
+//	{s}         -  The stream to reduce
+//	{identity}  -  The initial value of the accumulation of every segment. Must not alter the result when combined, such
+//	               as 0 for a sum or 1 for a product
+//	{acc}       -  Accumulates an element of the stream into the partial result of a segment
+//	{combine}   -  Combines the partial results of two segments
+//	{threads}   -  The amount of go routines to be used. <= 0 indicates the maximum amount of available CPUs will be the
+//	               number that determines the amount of go routines to be used
+func ParallelReduce[T comparable, R any](s IStream[T], identity R, acc func(R, T) R, combine func(R, R) R, threads int) R {
+	arr := s.ToArray()
+	cores := getCores(threads)
+
+	if len(arr) < cores {
+		cores = len(arr)
+	}
+	if cores == 0 {
+		return identity
+	}
+
+	var wg sync.WaitGroup
+	results := make([]R, cores)
+	sliceSize := int(math.Ceil(float64(len(arr)) / float64(cores)))
+
+	wg.Add(cores)
+	for i := 0; i < cores; i++ {
+		go func(i int) {
+			defer wg.Done()
+			ret := identity
+			for j := i * sliceSize; j < (i+1)*sliceSize && j < len(arr); j++ {
+				ret = acc(ret, arr[j])
+			}
+			results[i] = ret
+		}(i)
+	}
+	wg.Wait()
+
+	ret := results[0]
+	for _, r := range results[1:] {
+		ret = combine(ret, r)
+	}
+	return ret
+}
+
+// Average calculates the arithmetic mean of the numeric elements of the stream. Returns `ok=false` if the stream
+// produced no elements, instead of the NaN that would be produced by dividing by zero.
+func Average[T INumber](s IStream[T]) (avg float64, ok bool) {
+	return AverageBy[T](s, func(x T) float64 { return float64(x) })
+}
+
+// AverageBy calculates the arithmetic mean of the values obtained by `keyFn` from every element of the stream, useful
+// to average a field of structs. Returns `ok=false` if the stream produced no elements.
+//
+//	Eg:   avgAge, ok := streams.AverageBy[*Person](stream, func(p *Person) float64 { return float64(p.Age) })
+func AverageBy[T comparable](s IStream[T], keyFn func(T) float64) (avg float64, ok bool) {
+	sum, count := 0.0, 0
+	s.ForEach(func(x T) {
+		sum += keyFn(x)
+		count++
+	})
+	if count == 0 {
+		return 0, false
+	}
+	return sum / float64(count), true
+}
@@ -0,0 +1,148 @@
+package streams
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinMax(t *testing.T) {
+	arr := make([]int, 1000)
+	for i := range arr {
+		arr[i] = rand.Intn(10000) - 5000
+	}
+
+	sorted := make([]int, len(arr))
+	copy(sorted, arr)
+	sort.Ints(sorted)
+
+	min, max, ok := MinMax[int](From[int](arr))
+	assert.True(t, ok)
+	assert.Equal(t, sorted[0], min)
+	assert.Equal(t, sorted[len(sorted)-1], max)
+
+	_, _, ok = MinMax[int](From[int]([]int{}))
+	assert.False(t, ok)
+}
+
+func TestSummaryStats(t *testing.T) {
+	stats := SummaryStats[int](From[int]([]int{4, 8, -2, 10, 5}))
+	assert.Equal(t, Stats[int]{Count: 5, Sum: 25, Min: -2, Max: 10, Average: 5}, stats)
+
+	floats := SummaryStats[float64](From[float64]([]float64{1.5, 2.5}).Filter(func(x float64) bool { return x > 2 }))
+	assert.Equal(t, Stats[float64]{Count: 1, Sum: 2.5, Min: 2.5, Max: 2.5, Average: 2.5}, floats)
+
+	assert.Equal(t, Stats[int]{}, SummaryStats[int](From[int]([]int{})))
+}
+
+func TestCountDistinct(t *testing.T) {
+	arr := []string{"pear", "apple", "pear", "kiwi", "apple", "pear"}
+
+	assert.Equal(t, 3, From[string](arr).CountDistinct())
+	assert.Equal(t, 0, From[string]([]string{}).CountDistinct())
+
+	assert.Equal(t, 4, CountDistinctBy[string, int](From[string](testArray), func(x string) int { return len(x) }))
+	assert.Equal(t, 2, CountDistinctBy[string, int](From[string](arr), func(x string) int { return len(x) }))
+}
+
+func TestTopKFrequent(t *testing.T) {
+	tags := []string{"go", "rust", "go", "java", "rust", "go", "python", "java", "rust", "c"}
+
+	assert.Equal(t, []string{"go", "rust"}, TopKFrequent[string](From[string](tags), 2).ToArray())
+
+	// Ties keep the order they were first found
+	assert.Equal(t, []string{"go", "rust", "java", "python"}, TopKFrequent[string](From[string](tags), 4).ToArray())
+	assert.Equal(t, 5, TopKFrequent[string](From[string](tags), 10).Len())
+	assert.Equal(t, 0, TopKFrequent[string](From[string](tags), 0).Len())
+}
+
+func TestFrequencyRanked(t *testing.T) {
+	tags := []string{"rust", "go", "java", "go", "rust", "go", "c"}
+
+	ranked := FrequencyRanked[string](From[string](tags))
+	assert.Equal(t, []*KeyValuePair[string, int]{
+		NewPair("go", 3),
+		NewPair("rust", 2),
+		NewPair("c", 1),
+		NewPair("java", 1),
+	}, ranked.ToArray())
+
+	// Elements without a natural order keep the order they were first found
+	a, b, c := &testStruct{A: "a"}, &testStruct{A: "b"}, &testStruct{A: "c"}
+	structs := FrequencyRanked[*testStruct](From[*testStruct]([]*testStruct{c, a, b, a}))
+	assert.Equal(t, []*KeyValuePair[*testStruct, int]{NewPair(a, 2), NewPair(c, 1), NewPair(b, 1)}, structs.ToArray())
+
+	assert.Equal(t, 0, FrequencyRanked[int](From[int]([]int{})).Len())
+}
+
+func TestFold(t *testing.T) {
+	words := []string{"a", "b", "c"}
+
+	left := FoldLeft[string, string](From[string](words), "", func(acc string, x string) string {
+		return "(" + acc + "+" + x + ")"
+	})
+	right := FoldRight[string, string](From[string](words), "", func(x string, acc string) string {
+		return "(" + x + "+" + acc + ")"
+	})
+
+	assert.Equal(t, "(((+a)+b)+c)", left)
+	assert.Equal(t, "(a+(b+(c+)))", right)
+
+	reversed := FoldRight[string, string](FromChannel[string](newTestChannel(words...)), "", func(x string, acc string) string {
+		return acc + x
+	})
+	assert.Equal(t, "cba", reversed)
+
+	assert.Equal(t, 10, FoldLeft[int, int](From[int]([]int{}), 10, func(acc int, x int) int { return acc + x }))
+}
+
+func TestParallelReduce(t *testing.T) {
+	arr := make([]int, 100000)
+	expected := 0
+	for i := range arr {
+		arr[i] = rand.Intn(1000)
+		expected += arr[i]
+	}
+
+	sum := func(r int, x int) int { return r + x }
+	result := ParallelReduce[int, int](From[int](arr), 0, sum, sum, -1)
+	assert.Equal(t, expected, result)
+
+	// The segments are combined in order, so non-commutative reductions are supported as well
+	words := []string{"a", "b", "c", "d", "e", "f", "g"}
+	concat := func(r string, x string) string { return r + x }
+	assert.Equal(t, "abcdefg", ParallelReduce[string, string](From[string](words), "", concat, concat, 3))
+
+	assert.Equal(t, 0, ParallelReduce[int, int](From[int]([]int{}), 0, sum, sum, 4))
+}
+
+func TestAverage(t *testing.T) {
+	avg, ok := Average[int](From[int]([]int{}))
+	assert.False(t, ok)
+	assert.Equal(t, 0.0, avg)
+
+	avg, ok = Average[int](From[int]([]int{7}))
+	assert.True(t, ok)
+	assert.Equal(t, 7.0, avg)
+
+	avg, ok = Average[float32](From[float32]([]float32{1, 2, 3, 4.5}))
+	assert.True(t, ok)
+	assert.Equal(t, 2.625, avg)
+}
+
+func TestAverageBy(t *testing.T) {
+	lengths := func(x string) float64 { return float64(len(x)) }
+
+	_, ok := AverageBy[string](From[string]([]string{}), lengths)
+	assert.False(t, ok)
+
+	avg, ok := AverageBy[string](From[string]([]string{"kiwi"}), lengths)
+	assert.True(t, ok)
+	assert.Equal(t, 4.0, avg)
+
+	avg, ok = AverageBy[string](From[string](testArray), lengths)
+	assert.True(t, ok)
+	assert.Equal(t, 5.375, avg)
+}
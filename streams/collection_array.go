@@ -10,6 +10,14 @@ type arrayCollection[T comparable] struct {
 	arr []T
 }
 
+func newArrayCollection[T comparable](arr []T) *arrayCollection[T] {
+	ret := &arrayCollection[T]{arr: arr}
+	base := &CollectionBase[T]{}
+	base.SetAbstract(ret)
+	ret.CollectionBase = base
+	return ret
+}
+
 func (c *arrayCollection[T]) Index(index int) (ret T, exists bool) {
 	if index < 0 || index >= c.Len() {
 		return
@@ -38,6 +46,42 @@ func (c *arrayCollection[T]) RemoveAt(index int, keepOrder ...bool) bool {
 	return true
 }
 
+func (c *arrayCollection[T]) SetIndex(index int, value T) bool {
+	if index < 0 || index >= c.Len() {
+		return false
+	}
+
+	c.arr[index] = value
+	c.modified()
+	return true
+}
+
+func (c *arrayCollection[T]) Fill(value T) bool {
+	for i := range c.arr {
+		c.arr[i] = value
+	}
+	c.modified()
+	return true
+}
+
+func (c *arrayCollection[T]) EnsureCapacity(n int) {
+	if cap(c.arr) >= n {
+		return
+	}
+	arr := make([]T, len(c.arr), n)
+	copy(arr, c.arr)
+	c.arr = arr
+}
+
+func (c *arrayCollection[T]) TrimToSize() {
+	if cap(c.arr) == len(c.arr) {
+		return
+	}
+	arr := make([]T, len(c.arr))
+	copy(arr, c.arr)
+	c.arr = arr
+}
+
 func (c *arrayCollection[T]) Len() int {
 	return len(c.arr)
 }
@@ -142,6 +142,55 @@ func (c *CollectionBaseNoIterator[T]) Distinct() ISet[T] {
 	return set
 }
 
+func (c *CollectionBaseNoIterator[T]) Union(other ICollection[T]) ISet[T] {
+	ret := NewSet[T]()
+	ret.Add(c.Distinct().ToArray()...)
+	ret.Add(other.ToArray()...)
+	return ret
+}
+
+func (c *CollectionBaseNoIterator[T]) Intersect(other ICollection[T]) ISet[T] {
+	distinct := c.Distinct()
+	ret := NewSet[T]()
+	other.ForEach(func(item T) {
+		if distinct.Contains(item) {
+			ret.Add(item)
+		}
+	})
+	return ret
+}
+
+func (c *CollectionBaseNoIterator[T]) Except(other ICollection[T]) ISet[T] {
+	ret := NewSet[T]()
+	ret.Add(c.Distinct().ToArray()...)
+	ret.Remove(other.ToArray()...)
+	return ret
+}
+
+func (c *CollectionBaseNoIterator[T]) Equals(other ICollection[T]) bool {
+	return collectionEquals[T](c, other)
+}
+
+func (c *CollectionBaseNoIterator[T]) EqualsUnordered(other ICollection[T]) bool {
+	return collectionEqualsUnordered[T](c, other)
+}
+
+// SetIndex is not supported by default, since replacing an element depends on the storage of the implementation
+func (c *CollectionBaseNoIterator[T]) SetIndex(_ int, _ T) bool {
+	return false
+}
+
+// Fill is not supported by default, since replacing the elements depends on the storage of the implementation
+func (c *CollectionBaseNoIterator[T]) Fill(_ T) bool {
+	return false
+}
+
+// EnsureCapacity does nothing by default, since the capacity depends on the storage of the implementation
+func (c *CollectionBaseNoIterator[T]) EnsureCapacity(_ int) {}
+
+// TrimToSize does nothing by default, since the capacity depends on the storage of the implementation
+func (c *CollectionBaseNoIterator[T]) TrimToSize() {}
+
 func (c *CollectionBaseNoIterator[T]) Stream() IStream[T] {
 	return FromCollection[T](c)
 }
@@ -163,3 +212,52 @@ func (c *CollectionBase[T]) SetAbstract(col IAbstractCollection[T]) {
 	c.CollectionBaseNoIterator.IAbstractCollection = col
 	c.CollectionBaseNoIterator.iAbstractCollectionIterator = c
 }
+
+// collectionEquals compares the elements of both collections one by one, in the order produced by their iterators
+func collectionEquals[T comparable](a, b ICollection[T]) bool {
+	if b == nil {
+		return false
+	}
+	if a.Len() >= 0 && b.Len() >= 0 && a.Len() != b.Len() {
+		return false
+	}
+
+	ia, ib := a.Iterator(), b.Iterator()
+	for x, y := ia.Current(), ib.Current(); ia.HasNext() && ib.HasNext(); x, y = ia.Next(), ib.Next() {
+		if x != y {
+			return false
+		}
+	}
+	return !ia.HasNext() && !ib.HasNext()
+}
+
+// collectionEqualsUnordered compares the elements of both collections as multisets, counting the occurrences of every
+// element
+func collectionEqualsUnordered[T comparable](a, b ICollection[T]) bool {
+	if b == nil {
+		return false
+	}
+	if a.Len() >= 0 && b.Len() >= 0 && a.Len() != b.Len() {
+		return false
+	}
+
+	counts := map[T]int{}
+	a.ForEach(func(x T) {
+		counts[x]++
+	})
+
+	iterator := b.Iterator()
+	for x := iterator.Current(); iterator.HasNext(); x = iterator.Next() {
+		if counts[x] == 0 {
+			return false
+		}
+		counts[x]--
+	}
+
+	for _, count := range counts {
+		if count != 0 {
+			return false
+		}
+	}
+	return true
+}
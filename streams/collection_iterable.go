@@ -0,0 +1,183 @@
+package streams
+
+import "sync"
+
+var (
+	// To ensure *iterableCollection implements ICollection on build
+	_ ICollection[string] = (*iterableCollection[string])(nil)
+)
+
+// iterableCollection is a read-only collection which lazily reads its elements from an iterator, such as the ones
+// obtained from a channel or a generator function. Elements are buffered as they are read from the source so the
+// collection can be iterated more than once. The size of the collection is unknown (-1) until all elements from the
+// source have been read.
+type iterableCollection[T comparable] struct {
+	source  IIterator[T]
+	buffer  []T
+	started bool
+	done    bool
+	mx      sync.Mutex
+
+	// onRead is invoked after elements are read from the source, once the lock is released, so it can safely use the
+	// collection again
+	onRead func()
+}
+
+func newIterableCollection[T comparable](source IIterator[T]) *iterableCollection[T] {
+	return &iterableCollection[T]{source: source}
+}
+
+func (c *iterableCollection[T]) Iterator() IIterator[T] {
+	ret := &bufferedIterator[T]{col: c}
+	ret.IndexBasedIterator = &IndexBasedIterator[T]{
+		IAbstractIndexBasedIterator: ret,
+	}
+	return ret
+}
+
+func (c *iterableCollection[T]) ForEach(f IterFunc[T]) {
+	c.Iterator().ForEachRemaining(f)
+}
+
+// Add is not supported by lazy collections, always returns false
+func (c *iterableCollection[T]) Add(_ ...T) bool {
+	return false
+}
+
+// AddFromIterator is not supported by lazy collections, always returns false
+func (c *iterableCollection[T]) AddFromIterator(_ IIterator[T]) bool {
+	return false
+}
+
+// Remove is not supported by lazy collections, always returns false
+func (c *iterableCollection[T]) Remove(_ ...T) bool {
+	return false
+}
+
+// RemoveFromIterator is not supported by lazy collections, always returns false
+func (c *iterableCollection[T]) RemoveFromIterator(_ IIterator[T]) bool {
+	return false
+}
+
+// RemoveIf is not supported by lazy collections, always returns false
+func (c *iterableCollection[T]) RemoveIf(_ ConditionalFunc[T], _ ...bool) bool {
+	return false
+}
+
+func (c *iterableCollection[T]) Contains(items ...T) bool {
+	for _, item := range items {
+		if !anyMatch[T](c, 0, -1, func(x T) bool { return x == item }, false) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *iterableCollection[T]) ContainsFromIterator(iterator IIterator[T]) bool {
+	ret := true
+	iterator.ForEachRemaining(func(item T) {
+		ret = ret && c.Contains(item)
+	})
+	return ret
+}
+
+func (c *iterableCollection[T]) Len() int {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if c.done {
+		return len(c.buffer)
+	}
+	return -1
+}
+
+// Clear is not supported by lazy collections
+func (c *iterableCollection[T]) Clear() {
+}
+
+func (c *iterableCollection[T]) ToArray() []T {
+	defer c.notifyRead()
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	for !c.done {
+		c.pull()
+	}
+	return c.buffer
+}
+
+func (c *iterableCollection[T]) IsEmpty() bool {
+	_, ok := c.fetch(0)
+	return !ok
+}
+
+func (c *iterableCollection[T]) Equals(other ICollection[T]) bool {
+	return collectionEquals[T](c, other)
+}
+
+func (c *iterableCollection[T]) EqualsUnordered(other ICollection[T]) bool {
+	return collectionEqualsUnordered[T](c, other)
+}
+
+// fetch reads elements from the source until the element at the provided index is buffered. Returns false if the
+// source has no more elements before reaching the index.
+func (c *iterableCollection[T]) fetch(index int) (val T, ok bool) {
+	defer c.notifyRead()
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	for len(c.buffer) <= index && !c.done {
+		c.pull()
+	}
+	if index < 0 || index >= len(c.buffer) {
+		return
+	}
+	return c.buffer[index], true
+}
+
+// notifyRead invokes the onRead callback, if any. Must be called without holding the lock.
+func (c *iterableCollection[T]) notifyRead() {
+	if c.onRead != nil {
+		c.onRead()
+	}
+}
+
+// buffered returns the amount of elements read from the source so far
+func (c *iterableCollection[T]) buffered() int {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	return len(c.buffer)
+}
+
+// pull reads the next element from the source into the buffer. Must be called while holding the lock.
+func (c *iterableCollection[T]) pull() {
+	if c.started {
+		c.source.MoveNext()
+	}
+	c.started = true
+
+	if !c.source.HasNext() {
+		c.done = true
+		return
+	}
+	c.buffer = append(c.buffer, c.source.Current())
+}
+
+// bufferedIterator iterates over the elements of an iterableCollection, reading from its source when reaching the end
+// of the elements buffered so far.
+type bufferedIterator[T comparable] struct {
+	*IndexBasedIterator[T]
+	col *iterableCollection[T]
+}
+
+func (iter *bufferedIterator[T]) Current() (ret T) {
+	ret, _ = iter.col.fetch(iter.currentIndex)
+	return
+}
+
+// Len returns the amount of elements buffered after attempting to read up to the current position of the iterator,
+// which is enough for the index based iterator to determine whether the current position holds an element.
+func (iter *bufferedIterator[T]) Len() int {
+	iter.col.fetch(iter.currentIndex)
+	return iter.col.buffered()
+}
@@ -1,6 +1,9 @@
 package streams
 
-import "sync"
+import (
+	"sort"
+	"sync"
+)
 
 var (
 	// To ensure implementations on build
@@ -34,6 +37,30 @@ func (col *mapCollection[K, V]) Set(key K, value V) bool {
 	return true
 }
 
+func (col *mapCollection[K, V]) ComputeIfAbsent(k K, f func(K) V) V {
+	col.mx.Lock()
+	defer col.mx.Unlock()
+	if val, ok := col.m[k]; ok {
+		return val
+	}
+	val := f(k)
+	col.m[k] = val
+	col.keys = append(col.keys, k)
+	return val
+}
+
+func (col *mapCollection[K, V]) Compute(k K, f func(K, V, bool) V) V {
+	col.mx.Lock()
+	defer col.mx.Unlock()
+	current, ok := col.m[k]
+	val := f(k, current, ok)
+	col.m[k] = val
+	if !ok {
+		col.keys = append(col.keys, k)
+	}
+	return val
+}
+
 func (col *mapCollection[K, V]) ContainsKey(k K) bool {
 	_, ok := col.Get(k)
 	return ok
@@ -54,6 +81,25 @@ func (col *mapCollection[K, V]) Keys() []K {
 	return ret
 }
 
+func (col *mapCollection[K, V]) SortedKeys(cmp ...SortFunc[K]) []K {
+	keys := col.Keys()
+	ret := make([]K, len(keys))
+	copy(ret, keys)
+
+	fn := naturalCompare[K]
+	if len(cmp) > 0 && cmp[0] != nil {
+		fn = cmp[0]
+	} else if !hasNaturalOrder[K]() {
+		// Keys without a natural order are kept in insertion order
+		return ret
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return fn(ret[i], ret[j]) < 0
+	})
+	return ret
+}
+
 func (col *mapCollection[K, V]) Delete(k K) bool {
 	col.mx.Lock()
 	defer col.mx.Unlock()
@@ -67,7 +113,7 @@ func (col *mapCollection[K, V]) Delete(k K) bool {
 		if k != key {
 			continue
 		}
-		col.keys = append(col.keys[:i], col.keys[i:]...)
+		col.keys = append(col.keys[:i], col.keys[i+1:]...)
 		return true
 	}
 	return false
@@ -93,6 +139,9 @@ func (col *mapCollection[K, V]) Add(items ...*KeyValuePair[K, V]) (ret bool) {
 	col.mx.Lock()
 	defer col.mx.Unlock()
 	for _, item := range items {
+		if _, ok := col.m[item.Key]; !ok {
+			col.keys = append(col.keys, item.Key)
+		}
 		col.m[item.Key] = item.Value
 	}
 	return len(items) > 0
@@ -100,12 +149,34 @@ func (col *mapCollection[K, V]) Add(items ...*KeyValuePair[K, V]) (ret bool) {
 
 func (col *mapCollection[K, V]) RemoveAt(index int, _ ...bool) bool {
 	keys := col.Keys()
-	if index < 0 || len(keys) >= index {
+	if index < 0 || len(keys) <= index {
 		return false
 	}
 	return col.Delete(keys[index])
 }
 
+func (col *mapCollection[K, V]) EnsureCapacity(n int) {
+	col.mx.Lock()
+	defer col.mx.Unlock()
+	if cap(col.keys) >= n {
+		return
+	}
+	keys := make([]K, len(col.keys), n)
+	copy(keys, col.keys)
+	col.keys = keys
+}
+
+func (col *mapCollection[K, V]) TrimToSize() {
+	col.mx.Lock()
+	defer col.mx.Unlock()
+	if cap(col.keys) == len(col.keys) {
+		return
+	}
+	keys := make([]K, len(col.keys))
+	copy(keys, col.keys)
+	col.keys = keys
+}
+
 func (col *mapCollection[K, V]) Len() int {
 	col.mx.RLock()
 	defer col.mx.RUnlock()
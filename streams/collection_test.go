@@ -0,0 +1,369 @@
+package streams
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedSet(t *testing.T) {
+	set := NewSortedSet[int](ComparableFn[int]())
+
+	assert.True(t, set.Add(5, 1, 9, 3))
+	assert.True(t, set.Add(7, 1))
+	assert.False(t, set.Add(9))
+	assert.Equal(t, []int{1, 3, 5, 7, 9}, set.ToArray())
+
+	var iterated []int
+	set.ForEach(func(x int) {
+		iterated = append(iterated, x)
+	})
+	assert.Equal(t, []int{1, 3, 5, 7, 9}, iterated)
+
+	assert.True(t, set.Contains(3, 7))
+	assert.False(t, set.Contains(3, 4))
+	assert.True(t, set.Remove(3))
+	assert.Equal(t, []int{1, 5, 7, 9}, From[int](set).ToArray())
+}
+
+func TestSortedSet_Desc(t *testing.T) {
+	set := NewSortedSet[string](ComparableFn[string](true))
+	set.Add("peach", "apple", "pear", "apple")
+
+	assert.Equal(t, 3, set.Len())
+	assert.Equal(t, "pear,peach,apple", strings.Join(set.ToArray(), ","))
+}
+
+func TestOrderedSet(t *testing.T) {
+	set := NewOrderedSet[string]()
+
+	assert.True(t, set.Add("pear", "apple", "peach", "apple"))
+	assert.False(t, set.Add("pear"))
+	assert.True(t, set.Add("kiwi"))
+	assert.Equal(t, []string{"pear", "apple", "peach", "kiwi"}, set.ToArray())
+
+	var iterated []string
+	set.ForEach(func(x string) {
+		iterated = append(iterated, x)
+	})
+	assert.Equal(t, []string{"pear", "apple", "peach", "kiwi"}, iterated)
+
+	assert.True(t, set.Contains("kiwi", "pear"))
+	assert.True(t, set.Remove("apple"))
+	assert.False(t, set.Remove("apple"))
+	assert.True(t, set.Add("apple"))
+	assert.True(t, set.Contains("peach", "kiwi"))
+	assert.Equal(t, []string{"pear", "peach", "kiwi", "apple"}, From[string](set).ToArray())
+}
+
+func TestIdentitySet(t *testing.T) {
+	type item struct {
+		Tags []string
+	}
+
+	a := &item{Tags: []string{"x"}}
+	b := &item{Tags: []string{"x"}}
+
+	set := NewIdentitySet[item]()
+	assert.True(t, set.Add(a))
+	assert.False(t, set.Add(a))
+	assert.Equal(t, 1, set.Len())
+
+	// Same contents but a different pointer
+	assert.True(t, set.Add(b))
+	assert.Equal(t, 2, set.Len())
+	assert.True(t, set.Contains(a, b))
+	assert.False(t, set.Contains(&item{}))
+}
+
+func TestList_SetOperations(t *testing.T) {
+	a := NewList[int]([]int{1, 2, 3, 3, 4})
+	b := NewList[int]([]int{3, 4, 5, 5, 6})
+
+	union := a.Union(b)
+	assert.Equal(t, 6, union.Len())
+	assert.True(t, union.Contains(1, 2, 3, 4, 5, 6))
+
+	intersect := a.Intersect(b)
+	assert.Equal(t, []int{3, 4}, From[int](intersect).Sort(ComparableFn[int]()).ToArray())
+
+	except := a.Except(b)
+	assert.Equal(t, []int{1, 2}, From[int](except).Sort(ComparableFn[int]()).ToArray())
+
+	reversed := b.Except(a)
+	assert.Equal(t, []int{5, 6}, From[int](reversed).Sort(ComparableFn[int]()).ToArray())
+
+	// The cached distinct set of the list is not modified by the operations
+	assert.Equal(t, 4, a.Distinct().Len())
+}
+
+func TestCollection_Equals(t *testing.T) {
+	list := NewList[int]([]int{1, 2, 3, 2})
+
+	assert.True(t, list.Equals(NewList[int]([]int{1, 2, 3, 2})))
+	assert.False(t, list.Equals(NewList[int]([]int{2, 1, 3, 2})))
+	assert.False(t, list.Equals(NewList[int]([]int{1, 2, 3})))
+	assert.False(t, list.Equals(nil))
+	assert.True(t, NewList[int]().Equals(NewList[int]()))
+
+	// Lazy collections
+	assert.True(t, list.Equals(newIterableCollection[int](newChannelIterator[int](newTestChannel(1, 2, 3, 2)))))
+	assert.False(t, list.Equals(newIterableCollection[int](newChannelIterator[int](newTestChannel(1, 2, 3)))))
+
+	ordered := NewOrderedSet[string]()
+	ordered.Add("pear", "apple", "kiwi")
+	assert.True(t, ordered.Equals(NewList[string]([]string{"pear", "apple", "kiwi"})))
+	assert.False(t, ordered.Equals(NewList[string]([]string{"apple", "pear", "kiwi"})))
+
+	sorted := NewSortedSet[string](ComparableFn[string]())
+	sorted.Add("pear", "apple", "kiwi")
+	assert.True(t, sorted.Equals(NewList[string]([]string{"apple", "kiwi", "pear"})))
+}
+
+func TestCollection_EqualsUnordered(t *testing.T) {
+	list := NewList[int]([]int{1, 2, 3, 2})
+
+	assert.True(t, list.EqualsUnordered(NewList[int]([]int{2, 3, 2, 1})))
+	assert.False(t, list.EqualsUnordered(NewList[int]([]int{1, 2, 3, 3})))
+	assert.False(t, list.EqualsUnordered(NewList[int]([]int{1, 2, 3})))
+	assert.False(t, list.EqualsUnordered(nil))
+
+	set := NewSet[string]()
+	set.Add("pear", "apple", "kiwi")
+	assert.True(t, set.EqualsUnordered(NewList[string]([]string{"kiwi", "pear", "apple"})))
+	assert.False(t, set.EqualsUnordered(NewList[string]([]string{"kiwi", "pear", "apple", "kiwi"})))
+
+	other := NewSet[string]()
+	other.Add("apple", "kiwi", "pear")
+	assert.True(t, set.EqualsUnordered(other))
+	assert.True(t, other.EqualsUnordered(set))
+
+	other.Remove("kiwi")
+	assert.False(t, set.EqualsUnordered(other))
+}
+
+func TestMap_SortedKeys(t *testing.T) {
+	m := NewMap[string, int](map[string]int{"pear": 1, "apple": 2, "plum": 3, "banana": 4})
+
+	assert.Equal(t, []string{"apple", "banana", "pear", "plum"}, m.SortedKeys())
+	assert.Equal(t, []string{"plum", "pear", "banana", "apple"}, m.SortedKeys(ComparableFn[string](true)))
+
+	type level int
+	levels := NewMap[level, string](map[level]string{3: "c", 1: "a", 2: "b"})
+	assert.Equal(t, []level{1, 2, 3}, levels.SortedKeys())
+
+	// Keys without a natural order are returned in insertion order
+	structs := NewMap[*testStruct, int]()
+	a, b := &testStruct{A: "a"}, &testStruct{A: "b"}
+	structs.Set(b, 1)
+	structs.Set(a, 2)
+	assert.Equal(t, []*testStruct{b, a}, structs.SortedKeys())
+}
+
+func TestMap_KeysTracking(t *testing.T) {
+	m := NewMap[string, int]()
+	m.Add(&KeyValuePair[string, int]{Key: "pear", Value: 1}, &KeyValuePair[string, int]{Key: "apple", Value: 2})
+	m.Set("plum", 3)
+	m.Add(&KeyValuePair[string, int]{Key: "pear", Value: 10})
+	assert.Equal(t, []string{"pear", "apple", "plum"}, m.Keys())
+	assert.Equal(t, 3, m.Len())
+
+	// Deleting a key removes it from the keys
+	assert.True(t, m.Delete("apple"))
+	assert.False(t, m.Delete("apple"))
+	assert.Equal(t, []string{"pear", "plum"}, m.Keys())
+
+	// RemoveAt removes valid indexes only
+	assert.False(t, m.RemoveAt(2))
+	assert.False(t, m.RemoveAt(-1))
+	assert.True(t, m.RemoveAt(1))
+	assert.Equal(t, []string{"pear"}, m.Keys())
+
+	pair, ok := m.Index(0)
+	assert.True(t, ok)
+	assert.Equal(t, &KeyValuePair[string, int]{Key: "pear", Value: 10}, pair)
+}
+
+func TestNewOrderedMap(t *testing.T) {
+	m := NewOrderedMap[string, int](
+		&KeyValuePair[string, int]{Key: "pear", Value: 1},
+		&KeyValuePair[string, int]{Key: "apple", Value: 2},
+		&KeyValuePair[string, int]{Key: "plum", Value: 3},
+	)
+	assert.Equal(t, []string{"pear", "apple", "plum"}, m.Keys())
+
+	m.Set("banana", 4)
+	m.Set("apple", 20)
+	assert.Equal(t, []string{"pear", "apple", "plum", "banana"}, m.Keys())
+
+	var values []int
+	m.ForEach(func(pair *KeyValuePair[string, int]) {
+		values = append(values, pair.Value)
+	})
+	assert.Equal(t, []int{1, 20, 3, 4}, values)
+
+	pairs := m.ToArray()
+	assert.Equal(t, "banana", pairs[3].Key)
+
+	assert.True(t, m.Delete("apple"))
+	assert.True(t, m.RemoveAt(0))
+	assert.Equal(t, []string{"plum", "banana"}, m.Keys())
+}
+
+func TestMap_ComputeIfAbsent(t *testing.T) {
+	m := NewMap[string, int]()
+	calls := 0
+	length := func(k string) int {
+		calls++
+		return len(k)
+	}
+
+	assert.Equal(t, 5, m.ComputeIfAbsent("apple", length))
+	assert.Equal(t, 5, m.ComputeIfAbsent("apple", length))
+	assert.Equal(t, 1, calls)
+
+	m.Set("pear", 10)
+	assert.Equal(t, 10, m.ComputeIfAbsent("pear", length))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, []string{"apple", "pear"}, m.Keys())
+}
+
+func TestMap_Compute(t *testing.T) {
+	m := NewMap[string, int]()
+	count := func(_ string, current int, exists bool) int {
+		if !exists {
+			return 1
+		}
+		return current + 1
+	}
+
+	for _, x := range []string{"pear", "apple", "pear", "pear"} {
+		m.Compute(x, count)
+	}
+
+	assert.Equal(t, map[string]int{"pear": 3, "apple": 1}, m.ToMap())
+	assert.Equal(t, []string{"pear", "apple"}, m.Keys())
+	assert.Equal(t, 4, m.Compute("pear", count))
+}
+
+func TestNewPair(t *testing.T) {
+	pair := NewPair("apple", 5)
+	assert.Equal(t, &KeyValuePair[string, int]{Key: "apple", Value: 5}, pair)
+	assert.Equal(t, "apple:5", pair.String())
+	assert.Equal(t, "[apple:5 pear:2]", fmt.Sprint([]*KeyValuePair[string, int]{pair, NewPair("pear", 2)}))
+
+	m := NewOrderedMap[string, int](NewPair("pear", 1), NewPair("apple", 2))
+	assert.Equal(t, []string{"pear", "apple"}, m.Keys())
+}
+
+func TestNewListCap(t *testing.T) {
+	const size = 1000
+
+	capAllocs := testing.AllocsPerRun(10, func() {
+		list := NewListCap[int](size)
+		for i := 0; i < size; i++ {
+			list.Add(i)
+		}
+	})
+	listAllocs := testing.AllocsPerRun(10, func() {
+		list := NewList[int]()
+		for i := 0; i < size; i++ {
+			list.Add(i)
+		}
+	})
+
+	assert.Less(t, capAllocs, listAllocs)
+
+	list := NewListCap[int](size)
+	assert.Equal(t, 0, list.Len())
+	assert.Equal(t, size, cap(list.ToArray()))
+}
+
+func TestList_Fill(t *testing.T) {
+	list := NewList[int]([]int{1, 2, 3})
+	assert.True(t, list.Contains(1))
+
+	assert.True(t, list.Fill(7))
+	assert.Equal(t, []int{7, 7, 7}, list.ToArray())
+	assert.False(t, list.Contains(1))
+
+	empty := NewList[int]()
+	assert.True(t, empty.Fill(7))
+	assert.Equal(t, 0, empty.Len())
+
+	// Not supported by sets and maps
+	set := newOrderedSet[int]()
+	set.Add(1, 2)
+	assert.False(t, set.Fill(7))
+	assert.Equal(t, []int{1, 2}, set.ToArray())
+
+	m := NewMap[string, int](map[string]int{"a": 1})
+	assert.False(t, m.Fill(NewPair("b", 2)))
+	assert.Equal(t, map[string]int{"a": 1}, m.ToMap())
+}
+
+func TestList_SetIndex(t *testing.T) {
+	list := NewList[string]([]string{"pear", "apple", "kiwi"})
+	assert.True(t, list.Contains("apple"))
+
+	assert.True(t, list.SetIndex(1, "plum"))
+	assert.Equal(t, []string{"pear", "plum", "kiwi"}, list.ToArray())
+	assert.True(t, list.Contains("plum"))
+	assert.False(t, list.Contains("apple"))
+
+	assert.False(t, list.SetIndex(3, "banana"))
+	assert.False(t, list.SetIndex(-1, "banana"))
+	assert.Equal(t, 3, list.Len())
+
+	set := newOrderedSet[string]()
+	set.Add("pear", "apple", "kiwi")
+	assert.True(t, set.SetIndex(1, "plum"))
+	assert.False(t, set.SetIndex(0, "kiwi"))
+	assert.True(t, set.SetIndex(2, "kiwi"))
+	assert.Equal(t, []string{"pear", "plum", "kiwi"}, set.ToArray())
+	assert.True(t, set.Contains("plum"))
+	assert.False(t, set.Contains("apple"))
+}
+
+func TestList_EnsureCapacity(t *testing.T) {
+	list := NewList[int]([]int{1, 2, 3})
+
+	list.EnsureCapacity(100)
+	assert.Equal(t, 100, cap(list.ToArray()))
+	assert.Equal(t, []int{1, 2, 3}, list.ToArray())
+
+	// Never shrinks the list
+	list.EnsureCapacity(2)
+	assert.Equal(t, 100, cap(list.ToArray()))
+
+	list.Add(4, 5)
+	list.TrimToSize()
+	assert.Equal(t, 5, cap(list.ToArray()))
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, list.ToArray())
+}
+
+func BenchmarkNewListCap(b *testing.B) {
+	const size = 10000
+
+	b.Run("NewList", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			list := NewList[int]()
+			for j := 0; j < size; j++ {
+				list.Add(j)
+			}
+		}
+	})
+
+	b.Run("NewListCap", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			list := NewListCap[int](size)
+			for j := 0; j < size; j++ {
+				list.Add(j)
+			}
+		}
+	})
+}
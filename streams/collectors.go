@@ -0,0 +1,332 @@
+package streams
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+/** This file provides package level terminal functions that collect the result of a stream into a different type **/
+
+// Collect performs a general reduction of the elements of the stream using the three functions of a collector.
+//
+//	{s}            -  The stream to collect
+//	{supplier}     -  Creates the mutable container where the elements will be accumulated
+//	{accumulator}  -  Incorporates an element of the stream into the container
+//	{finisher}     -  Transforms the container into the final result
+//
+//	Eg:   joined := streams.Collect[string, *strings.Builder, string](stream,
+//	          func() *strings.Builder { return &strings.Builder{} },
+//	          func(b *strings.Builder, x string) { b.WriteString(x) },
+//	          func(b *strings.Builder) string { return b.String() })
+//
+// NOTE: Golang generics do not allow passing generics to functions that are part of a structure or interface, so this
+// function could not be a part of IStream.
+func Collect[T comparable, A any, R any](s IStream[T], supplier func() A, accumulator func(A, T), finisher func(A) R) R {
+	container := supplier()
+	s.ForEach(func(x T) {
+		accumulator(container, x)
+	})
+	return finisher(container)
+}
+
+// GroupReduce groups the elements of the stream by the key produced by `keyFn` and reduces the elements of every group
+// in a single pass, without keeping the elements of the groups in memory. The keys of the resulting map are kept in the
+// order they were first found.
+//
+//	{s}         -  The stream to group
+//	{keyFn}     -  Produces the key of the group of an element
+//	{identity}  -  The initial value of the reduction of every group
+//	{acc}       -  Accumulates an element into the result of its group
+//
+//	Eg:   salesPerRegion := streams.GroupReduce[*Sale, string, float64](stream,
+//	          func(s *Sale) string { return s.Region },
+//	          0,
+//	          func(total float64, s *Sale) float64 { return total + s.Amount })
+func GroupReduce[T comparable, K comparable, R any](s IStream[T], keyFn func(T) K, identity R, acc func(R, T) R) IMap[K, R] {
+	ret := NewMap[K, R]()
+	s.ForEach(func(x T) {
+		k := keyFn(x)
+		current, ok := ret.Get(k)
+		if !ok {
+			current = identity
+		}
+		ret.Set(k, acc(current, x))
+	})
+	return ret
+}
+
+// GroupByMulti groups the elements of the stream by the first key function, then the elements of every group by the
+// second key function, and so on, producing nested groupings which are useful for pivot-table style aggregations. The
+// groups of the last level have no sub groups (`Groups` is nil). The keys of every level are kept in the order they were
+// first found. Panics if no key functions are provided.
+//
+//	{s}       -  The stream to group
+//	{keyFns}  -  The key functions of every level of the grouping
+//
+//	Eg:   byRegionAndYear := streams.GroupByMulti[*Sale, string](stream,
+//	          func(s *Sale) string { return s.Region },
+//	          func(s *Sale) string { return s.Year })
+//
+//	      north, _ := byRegionAndYear.Get("north")
+//	      north2024, _ := north.Groups.Get("2024")
+func GroupByMulti[T comparable, K comparable](s IStream[T], keyFns ...func(T) K) IMap[K, *Grouping[T, K]] {
+	if len(keyFns) == 0 {
+		panic("at least one key function is required to group the elements")
+	}
+
+	ret := NewMap[K, *Grouping[T, K]]()
+	s.ForEach(func(x T) {
+		groups := ret
+		for i, keyFn := range keyFns {
+			k := keyFn(x)
+			group, ok := groups.Get(k)
+			if !ok {
+				group = &Grouping[T, K]{Key: k, Items: NewList[T]()}
+				if i < len(keyFns)-1 {
+					group.Groups = NewMap[K, *Grouping[T, K]]()
+				}
+				groups.Set(k, group)
+			}
+			_ = group.Items.Add(x)
+			groups = group.Groups
+		}
+	})
+	return ret
+}
+
+// GroupToLists groups the elements of the stream by the key produced by `keyFn` into a plain Go map of slices, keeping
+// the order in which the elements were found within every slice. A simpler alternative to `GroupByMulti` for a single
+// level of grouping, which does not wrap the groups into collections.
+//
+//	{s}      -  The stream to group
+//	{keyFn}  -  Produces the key of the group of an element
+//
+//	Eg:   byLength := streams.GroupToLists[string, int](stream, func(s string) int { return len(s) })
+func GroupToLists[T comparable, K comparable](s IStream[T], keyFn func(T) K) map[K][]T {
+	ret := map[K][]T{}
+	s.ForEach(func(x T) {
+		k := keyFn(x)
+		ret[k] = append(ret[k], x)
+	})
+	return ret
+}
+
+// ToArrayAs processes the stream and converts every element of the result with the provided function, returning the
+// converted elements in an array. Equivalent to mapping the stream and calling ToArray, but in a single pass and without
+// requiring the converted type to be comparable.
+//
+//	Eg:   names := streams.ToArrayAs[*Person, string](stream, func(p *Person) string { return p.Name })
+func ToArrayAs[T comparable, R any](s IStream[T], conv func(T) R) []R {
+	var ret []R
+	s.ForEach(func(x T) {
+		ret = append(ret, conv(x))
+	})
+	return ret
+}
+
+// IndexBy builds a lookup map of the elements of the stream keyed by the identifier produced by `keyFn`, such as indexing
+// structs by their ID. If more than one element produces the same key, the last one processed is kept. The keys of the
+// resulting map are kept in the order they were first found.
+//
+//	Eg:   byId := streams.IndexBy[*Person, int](stream, func(p *Person) int { return p.Id })
+func IndexBy[T comparable, K comparable](s IStream[T], keyFn func(T) K) IMap[K, T] {
+	ret := NewMap[K, T]()
+	s.ForEach(func(x T) {
+		ret.Set(keyFn(x), x)
+	})
+	return ret
+}
+
+// Associate builds a map from the elements of the stream, where the provided function produces both the key and the
+// value of every element. If more than one element produces the same key, the last one processed is kept. The keys of
+// the resulting map are kept in the order they were first found.
+//
+//	Eg:   lengths := streams.Associate[string, string, int](stream, func(x string) (string, int) { return x, len(x) })
+func Associate[T comparable, K, V comparable](s IStream[T], transform func(T) (K, V)) IMap[K, V] {
+	ret := NewMap[K, V]()
+	s.ForEach(func(x T) {
+		ret.Set(transform(x))
+	})
+	return ret
+}
+
+// AssociateWith builds a map where every element of the stream is a key, mapped to the value computed for it by the
+// provided function, such as the length of every word. The keys of the resulting map are kept in the order they were
+// first found.
+func AssociateWith[T comparable, V comparable](s IStream[T], valueFn func(T) V) IMap[T, V] {
+	return Associate[T, T, V](s, func(x T) (T, V) {
+		return x, valueFn(x)
+	})
+}
+
+// AssociateBy builds a map where every element of the stream is a value, keyed by the key produced for it by the
+// provided function. Equivalent to `IndexBy`, where the last element producing a key is kept.
+func AssociateBy[T comparable, K comparable](s IStream[T], keyFn func(T) K) IMap[K, T] {
+	return IndexBy[T, K](s, keyFn)
+}
+
+// GroupMapValues groups the elements of the stream by the key produced by `keyFn`, projecting every element into the
+// value produced by `valFn`, such as collecting the emails of the users of every domain. The order in which the
+// elements were found is kept within every slice.
+//
+//	{s}      -  The stream to group
+//	{keyFn}  -  Produces the key of the group of an element
+//	{valFn}  -  Produces the value collected into the group for an element
+//
+//	Eg:   emailsPerDomain := streams.GroupMapValues[*User, string, string](stream,
+//	          func(u *User) string { return u.Domain },
+//	          func(u *User) string { return u.Email })
+func GroupMapValues[T comparable, K comparable, V comparable](s IStream[T], keyFn func(T) K, valFn func(T) V) map[K][]V {
+	ret := map[K][]V{}
+	s.ForEach(func(x T) {
+		k := keyFn(x)
+		ret[k] = append(ret[k], valFn(x))
+	})
+	return ret
+}
+
+// Pairwise produces the consecutive overlapping pairs of elements of the stream `(e0, e1), (e1, e2), ...`, useful to
+// compute deltas between adjacent elements. Streams with less than 2 elements produce an empty list.
+//
+//	Eg:   deltas := streams.Map[[2]int, int](streams.Pairwise[int](stream), func(p [2]int) int { return p[1] - p[0] })
+//
+// NOTE: Pairwise could not be a part of IStream since a stream of pairs would also need to produce pairs of pairs,
+// which Golang generics do not allow as a method of a generic type.
+func Pairwise[T comparable](s IStream[T]) IList[[2]T] {
+	ret := NewList[[2]T]()
+	first := true
+	var prev T
+	s.ForEach(func(x T) {
+		if !first {
+			_ = ret.Add([2]T{prev, x})
+		}
+		prev, first = x, false
+	})
+	return ret
+}
+
+// GroupAdjacentBy groups the consecutive elements of the stream which share the key produced by `keyFn` into runs, in
+// the order of the stream. Unlike `GroupBy`, elements with the same key which are not adjacent produce separate runs,
+// which is useful over sorted or time ordered data, such as the sessions of a stream of events.
+//
+//	Eg:   streams.GroupAdjacentBy[int, int](streams.From[int]([]int{1, 1, 2, 2, 1}), identity)  // [[1 1] [2 2] [1]]
+func GroupAdjacentBy[T comparable, K comparable](s IStream[T], keyFn func(T) K) (ret [][]T) {
+	var run []T
+	var key K
+
+	s.ForEach(func(x T) {
+		k := keyFn(x)
+		if len(run) > 0 && k != key {
+			ret = append(ret, run)
+			run = nil
+		}
+		run = append(run, x)
+		key = k
+	})
+
+	if len(run) > 0 {
+		ret = append(ret, run)
+	}
+	return
+}
+
+// BucketByTime groups the elements of the stream into fixed width time windows, such as the events of every minute of a
+// time series. The windows are keyed by their start time in UTC, which is the time of the element produced by `timeFn`
+// truncated to a multiple of the window (see `time.Time.Truncate`), so elements at the same instant always share a
+// window regardless of their location. The order in which the elements were found is kept within every window. If the
+// window is <= 0, the elements are grouped by their exact time.
+//
+//	Eg:   perMinute := streams.BucketByTime[*Event](stream, func(e *Event) time.Time { return e.Time }, time.Minute)
+func BucketByTime[T comparable](s IStream[T], timeFn func(T) time.Time, window time.Duration) map[time.Time][]T {
+	ret := map[time.Time][]T{}
+	s.ForEach(func(x T) {
+		k := timeFn(x).UTC().Truncate(window)
+		ret[k] = append(ret[k], x)
+	})
+	return ret
+}
+
+// Coalesce combines the results of the provided streams position by position, producing for every index the first
+// element which is not the zero value of T among the streams, in the order they were provided, such as layering
+// defaults under overrides. The result is as long as the longest stream, where streams shorter than it are considered
+// to contain zero values past their end. If all the elements in a position are zero values, the zero value is produced.
+//
+//	Eg:   streams.Coalesce[int](streams.From[int]([]int{1, 0, 3}), streams.From[int]([]int{9, 2, 0}))  // [1 2 3]
+func Coalesce[T comparable](streams ...IStream[T]) IList[T] {
+	var zero T
+	var ret []T
+
+	for _, s := range streams {
+		i := 0
+		s.ForEach(func(x T) {
+			if i == len(ret) {
+				ret = append(ret, x)
+			} else if ret[i] == zero {
+				ret[i] = x
+			}
+			i++
+		})
+	}
+	return NewList[T](ret)
+}
+
+// ToSyncMap collects the elements of the stream into a *sync.Map, storing the value produced by `valFn` for the key
+// produced by `keyFn` of every element, so the result can be shared with concurrent readers and writers. If more than
+// one element produces the same key, the value of the last one processed is kept.
+//
+//	Eg:   cache := streams.ToSyncMap[*User, int, string](stream,
+//	          func(u *User) int { return u.Id },
+//	          func(u *User) string { return u.Name })
+func ToSyncMap[T comparable, K comparable, V comparable](s IStream[T], keyFn func(T) K, valFn func(T) V) *sync.Map {
+	ret := &sync.Map{}
+	s.ForEach(func(x T) {
+		ret.Store(keyFn(x), valFn(x))
+	})
+	return ret
+}
+
+// GroupCollect groups the elements of the stream by the key produced by `keyFn`, collecting the elements of every group
+// with the three functions of a collector, similar to `Collect`, which allows arbitrary per group aggregations.
+//
+//	{s}            -  The stream to collect
+//	{keyFn}        -  Produces the key of the group of an element
+//	{supplier}     -  Creates the mutable container where the elements of a group will be accumulated
+//	{accumulator}  -  Incorporates an element of the stream into the container of its group
+//	{finisher}     -  Transforms the container of every group into its final result
+//
+//	Eg:   namesPerTeam := streams.GroupCollect[*Player, string, *strings.Builder, string](stream,
+//	          func(p *Player) string { return p.Team },
+//	          func() *strings.Builder { return &strings.Builder{} },
+//	          func(b *strings.Builder, p *Player) { b.WriteString(p.Name) },
+//	          func(b *strings.Builder) string { return b.String() })
+func GroupCollect[T comparable, K comparable, A any, R any](s IStream[T], keyFn func(T) K, supplier func() A, accumulator func(A, T), finisher func(A) R) map[K]R {
+	containers := map[K]A{}
+	s.ForEach(func(x T) {
+		k := keyFn(x)
+		container, ok := containers[k]
+		if !ok {
+			container = supplier()
+			containers[k] = container
+		}
+		accumulator(container, x)
+	})
+
+	ret := make(map[K]R, len(containers))
+	for k, container := range containers {
+		ret[k] = finisher(container)
+	}
+	return ret
+}
+
+// ToJSONLines processes the stream and writes every element of the result to the provided writer as a JSON document on
+// its own line (JSON Lines or ndjson), such as large exports consumed by log pipelines. The elements are encoded and
+// written one at a time, without building the whole output in memory first. Stops at the first encoding or write
+// error, which is returned.
+func ToJSONLines[T comparable](s IStream[T], w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	return s.ForEachErr(func(x T) error {
+		return encoder.Encode(x)
+	})
+}
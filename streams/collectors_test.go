@@ -0,0 +1,374 @@
+package streams
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollect(t *testing.T) {
+	joined := Collect[string, *strings.Builder, string](
+		From[string]([]string{"peach", "apple", "pear", "plum"}).
+			Filter(func(x string) bool {
+				return strings.HasPrefix(x, "p")
+			}),
+		func() *strings.Builder {
+			return &strings.Builder{}
+		},
+		func(b *strings.Builder, x string) {
+			if b.Len() > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(x)
+		},
+		func(b *strings.Builder) string {
+			return b.String()
+		})
+
+	assert.Equal(t, "peach, pear, plum", joined)
+}
+
+func TestGroupReduce(t *testing.T) {
+	type sale struct {
+		Region string
+		Amount int
+	}
+
+	sales := []*sale{
+		{Region: "north", Amount: 10},
+		{Region: "south", Amount: 5},
+		{Region: "north", Amount: 7},
+		{Region: "east", Amount: 1},
+		{Region: "south", Amount: 3},
+	}
+
+	result := GroupReduce[*sale, string, int](From[*sale](sales),
+		func(s *sale) string { return s.Region },
+		0,
+		func(total int, s *sale) int { return total + s.Amount })
+
+	assert.Equal(t, map[string]int{"north": 17, "south": 8, "east": 1}, result.ToMap())
+	assert.Equal(t, []string{"north", "south", "east"}, result.Keys())
+}
+
+func TestGroupByMulti(t *testing.T) {
+	type sale struct {
+		Region string
+		Year   string
+		Amount int
+	}
+
+	sales := []*sale{
+		{Region: "north", Year: "2023", Amount: 10},
+		{Region: "south", Year: "2023", Amount: 5},
+		{Region: "north", Year: "2024", Amount: 7},
+		{Region: "north", Year: "2023", Amount: 1},
+		{Region: "south", Year: "2024", Amount: 3},
+	}
+
+	result := GroupByMulti[*sale, string](From[*sale](sales),
+		func(s *sale) string { return s.Region },
+		func(s *sale) string { return s.Year })
+
+	assert.Equal(t, []string{"north", "south"}, result.Keys())
+
+	north, ok := result.Get("north")
+	assert.True(t, ok)
+	assert.Equal(t, "north", north.Key)
+	assert.Equal(t, 3, north.Items.Len())
+	assert.Equal(t, []string{"2023", "2024"}, north.Groups.Keys())
+
+	north2023, _ := north.Groups.Get("2023")
+	assert.Equal(t, []*sale{sales[0], sales[3]}, north2023.Items.ToArray())
+	assert.Nil(t, north2023.Groups)
+
+	south2024, _ := result.ToMap()["south"].Groups.Get("2024")
+	assert.Equal(t, []*sale{sales[4]}, south2024.Items.ToArray())
+
+	assert.Panics(t, func() { GroupByMulti[*sale, string](From[*sale](sales)) })
+}
+
+func TestGroupToLists(t *testing.T) {
+	result := GroupToLists[string, int](From[string](testArray), func(x string) int {
+		return len(x)
+	})
+
+	assert.Equal(t, map[int][]string{
+		4: {"pear", "plum", "kiwi"},
+		5: {"peach", "apple"},
+		6: {"banana", "orange"},
+		9: {"pineapple"},
+	}, result)
+}
+
+func TestToArrayAs(t *testing.T) {
+	result := ToArrayAs[int, string](From[int]([]int{3, 1, 2}).Sort(ComparableFn[int]()), Mappers().IntToString())
+	assert.Equal(t, []string{"1", "2", "3"}, result)
+
+	assert.Empty(t, ToArrayAs[int, string](From[int]([]int{}), Mappers().IntToString()))
+}
+
+func TestIndexBy(t *testing.T) {
+	type user struct {
+		Id   int
+		Name string
+	}
+
+	users := []*user{
+		{Id: 3, Name: "carol"},
+		{Id: 1, Name: "alice"},
+		{Id: 2, Name: "bob"},
+		{Id: 1, Name: "alicia"},
+	}
+
+	result := IndexBy[*user, int](From[*user](users), func(u *user) int { return u.Id })
+
+	assert.Equal(t, 3, result.Len())
+	assert.Equal(t, []int{3, 1, 2}, result.Keys())
+
+	u, ok := result.Get(1)
+	assert.True(t, ok)
+	assert.Same(t, users[3], u)
+
+	_, ok = result.Get(4)
+	assert.False(t, ok)
+}
+
+func TestAssociate(t *testing.T) {
+	lengths := Associate[string, string, int](From[string](testArray), func(x string) (string, int) {
+		return x, len(x)
+	})
+
+	assert.Equal(t, len(testArray), lengths.Len())
+	assert.Equal(t, testArray, lengths.Keys())
+	assert.Equal(t, map[string]int{
+		"peach": 5, "apple": 5, "pear": 4, "plum": 4, "pineapple": 9, "banana": 6, "kiwi": 4, "orange": 6,
+	}, lengths.ToMap())
+
+	// The last element producing a key is kept
+	byLen := Associate[string, int, string](From[string](testArray), func(x string) (int, string) {
+		return len(x), x
+	})
+	assert.Equal(t, []int{5, 4, 9, 6}, byLen.Keys())
+	assert.Equal(t, map[int]string{5: "apple", 4: "kiwi", 9: "pineapple", 6: "orange"}, byLen.ToMap())
+}
+
+func TestAssociateWith(t *testing.T) {
+	words := []string{"pear", "apple", "pear", "kiwi"}
+
+	lengths := AssociateWith[string, int](From[string](words), func(x string) int { return len(x) })
+	assert.Equal(t, []string{"pear", "apple", "kiwi"}, lengths.Keys())
+	assert.Equal(t, map[string]int{"pear": 4, "apple": 5, "kiwi": 4}, lengths.ToMap())
+}
+
+func TestAssociateBy(t *testing.T) {
+	byFirst := AssociateBy[string, byte](From[string](testArray), func(x string) byte { return x[0] })
+
+	assert.Equal(t, []byte{'p', 'a', 'b', 'k', 'o'}, byFirst.Keys())
+	assert.Equal(t, map[byte]string{'p': "pineapple", 'a': "apple", 'b': "banana", 'k': "kiwi", 'o': "orange"}, byFirst.ToMap())
+}
+
+func TestGroupMapValues(t *testing.T) {
+	type user struct {
+		Name  string
+		Email string
+	}
+
+	users := []*user{
+		{Name: "alice", Email: "alice@example.com"},
+		{Name: "bob", Email: "bob@test.org"},
+		{Name: "carol", Email: "carol@example.com"},
+		{Name: "dave", Email: "dave@test.org"},
+		{Name: "erin", Email: "erin@example.com"},
+	}
+
+	result := GroupMapValues[*user, string, string](From[*user](users),
+		func(u *user) string { return u.Email[strings.Index(u.Email, "@")+1:] },
+		func(u *user) string { return u.Name })
+
+	assert.Equal(t, map[string][]string{
+		"example.com": {"alice", "carol", "erin"},
+		"test.org":    {"bob", "dave"},
+	}, result)
+}
+
+func TestPairwise(t *testing.T) {
+	assert.Equal(t, [][2]int{{1, 2}, {2, 3}}, Pairwise[int](From[int]([]int{1, 2, 3})).ToArray())
+	assert.Empty(t, Pairwise[int](From[int]([]int{1})).ToArray())
+	assert.Empty(t, Pairwise[int](From[int]([]int{})).ToArray())
+
+	deltas := Map[[2]int, int](Pairwise[int](From[int]([]int{3, 7, 4, 10})), func(p [2]int) int { return p[1] - p[0] })
+	assert.Equal(t, []int{4, -3, 6}, deltas.ToArray())
+}
+
+func TestGroupBy(t *testing.T) {
+	grouped := GroupBy[string, string](From[string](testArray), func(x string) string { return x[:1] })
+
+	assert.Equal(t, []string{"p", "a", "b", "k", "o"}, grouped.Keys())
+
+	counts := grouped.Count()
+	assert.Equal(t, map[string]int{"p": 4, "a": 1, "b": 1, "k": 1, "o": 1}, counts.ToMap())
+	assert.Equal(t, grouped.Keys(), counts.Keys())
+
+	longest := grouped.Reduce(func(acc string, x string) string {
+		if len(x) > len(acc) {
+			return x
+		}
+		return acc
+	})
+	assert.Equal(t, "pineapple", longest.ToMap()["p"])
+	assert.Equal(t, "kiwi", longest.ToMap()["k"])
+
+	perGroup := map[string][]string{}
+	grouped.ForEach(func(k string, s IStream[string]) {
+		perGroup[k] = s.Filter(func(x string) bool { return len(x) > 4 }).ToArray()
+	})
+	assert.Equal(t, []string{"peach", "pineapple"}, perGroup["p"])
+	assert.Empty(t, perGroup["k"])
+
+	p, _ := grouped.ToMap().Get("p")
+	assert.Equal(t, []string{"peach", "pear", "plum", "pineapple"}, p.ToArray())
+}
+
+func TestGroupAdjacentBy(t *testing.T) {
+	identity := func(x int) int { return x }
+
+	runs := GroupAdjacentBy[int, int](From[int]([]int{1, 1, 2, 2, 1}), identity)
+	assert.Equal(t, [][]int{{1, 1}, {2, 2}, {1}}, runs)
+
+	byLen := GroupAdjacentBy[string, int](From[string](testArray), func(x string) int { return len(x) })
+	assert.Equal(t, [][]string{{"peach", "apple"}, {"pear", "plum"}, {"pineapple"}, {"banana"}, {"kiwi"}, {"orange"}}, byLen)
+
+	assert.Empty(t, GroupAdjacentBy[int, int](From[int]([]int{}), identity))
+}
+
+func TestBucketByTime(t *testing.T) {
+	type event struct {
+		Name string
+		Time time.Time
+	}
+
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	events := []*event{
+		{Name: "a", Time: start.Add(5 * time.Second)},
+		{Name: "b", Time: start.Add(59 * time.Second)},
+		{Name: "c", Time: start.Add(time.Minute)},
+		{Name: "d", Time: start.Add(3*time.Minute + 30*time.Second)},
+		{Name: "e", Time: start.Add(90 * time.Second)},
+	}
+
+	buckets := BucketByTime[*event](From[*event](events), func(e *event) time.Time { return e.Time }, time.Minute)
+	assert.Equal(t, map[time.Time][]*event{
+		start:                      {events[0], events[1]},
+		start.Add(time.Minute):     {events[2], events[4]},
+		start.Add(3 * time.Minute): {events[3]},
+	}, buckets)
+
+	// The same instant in different locations shares the window
+	zone := time.FixedZone("UTC-3", -3*60*60)
+	zoned := []*event{
+		{Name: "a", Time: start.Add(10 * time.Second)},
+		{Name: "b", Time: start.Add(20 * time.Second).In(zone)},
+	}
+	buckets = BucketByTime[*event](From[*event](zoned), func(e *event) time.Time { return e.Time }, time.Minute)
+	assert.Equal(t, map[time.Time][]*event{start: zoned}, buckets)
+
+	assert.Empty(t, BucketByTime[*event](From[*event]([]*event{}), func(e *event) time.Time { return e.Time }, time.Minute))
+}
+
+func TestCoalesce(t *testing.T) {
+	result := Coalesce[int](From[int]([]int{1, 0, 3}), From[int]([]int{9, 2, 0}))
+	assert.Equal(t, []int{1, 2, 3}, result.ToArray())
+
+	// Shorter streams are considered to contain zero values past their end
+	layered := Coalesce[string](
+		From[string]([]string{"", "b"}),
+		From[string]([]string{"x", "y", "", ""}),
+		From[string]([]string{"1", "2", "3"}),
+	)
+	assert.Equal(t, []string{"x", "b", "3", ""}, layered.ToArray())
+
+	assert.Equal(t, 0, Coalesce[int]().Len())
+}
+
+func TestToSyncMap(t *testing.T) {
+	m := ToSyncMap[string, string, int](From[string](testArray),
+		func(x string) string { return x },
+		func(x string) int { return len(x) })
+
+	var wg sync.WaitGroup
+	wg.Add(len(testArray))
+	for _, x := range testArray {
+		go func(x string) {
+			defer wg.Done()
+			val, ok := m.Load(x)
+			assert.True(t, ok)
+			assert.Equal(t, len(x), val)
+			m.Store(x+"!", len(x)+1)
+		}(x)
+	}
+	wg.Wait()
+
+	val, ok := m.Load("kiwi!")
+	assert.True(t, ok)
+	assert.Equal(t, 5, val)
+
+	_, ok = m.Load("mango")
+	assert.False(t, ok)
+}
+
+func TestGroupCollect(t *testing.T) {
+	result := GroupCollect[string, int, *strings.Builder, string](From[string](testArray),
+		func(x string) int { return len(x) },
+		func() *strings.Builder { return &strings.Builder{} },
+		func(b *strings.Builder, x string) {
+			if b.Len() > 0 {
+				b.WriteString("+")
+			}
+			b.WriteString(x)
+		},
+		func(b *strings.Builder) string { return b.String() })
+
+	assert.Equal(t, map[int]string{
+		4: "pear+plum+kiwi",
+		5: "peach+apple",
+		6: "banana+orange",
+		9: "pineapple",
+	}, result)
+}
+
+func TestToJSONLines(t *testing.T) {
+	arr := []*testStruct{
+		{A: "pear", B: 1, C: "green"},
+		{A: "apple", B: 2, C: "red"},
+		{A: "kiwi", B: 3, C: "brown"},
+	}
+
+	buffer := new(bytes.Buffer)
+	err := ToJSONLines[*testStruct](From[*testStruct](arr).Filter(func(x *testStruct) bool { return x.B > 1 }), buffer)
+	assert.NoError(t, err)
+
+	var parsed []*testStruct
+	scanner := bufio.NewScanner(buffer)
+	for scanner.Scan() {
+		item := &testStruct{}
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), item))
+		parsed = append(parsed, item)
+	}
+	assert.Equal(t, arr[1:], parsed)
+
+	assert.Error(t, ToJSONLines[*testStruct](From[*testStruct](arr), &failingWriter{}))
+}
+
+type failingWriter struct{}
+
+func (*failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
@@ -0,0 +1,21 @@
+package streams
+
+import "errors"
+
+var (
+	// ErrTimeout is the error reported by `IStream.Err` when a terminal operation was aborted because the deadline set
+	// with `WithTimeout` was reached.
+	ErrTimeout = errors.New("stream operation timed out")
+
+	// ErrInvalidSource is the error produced when the source provided to create a stream, an iterator or a mapping is
+	// not any of the supported source types.
+	ErrInvalidSource = errors.New("invalid source")
+
+	// ErrWrongType is the error produced when an element is not of the type required by the operation, such as plucking
+	// a field of an element which is not a struct.
+	ErrWrongType = errors.New("wrong type")
+
+	// ErrIndexOutOfRange is the error produced by index based operations when the provided index is not within the
+	// bounds of the collection.
+	ErrIndexOutOfRange = errors.New("index out of range")
+)
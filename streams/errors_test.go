@@ -0,0 +1,67 @@
+package streams
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrInvalidSource(t *testing.T) {
+	s, err := TryFrom[string](testArray)
+	assert.NoError(t, err)
+	assert.Equal(t, testArray, s.ToArray())
+
+	s, err = TryFrom[string]([]int{1, 2})
+	assert.Nil(t, s)
+	assert.True(t, errors.Is(err, ErrInvalidSource))
+
+	assertPanicsWith(t, ErrInvalidSource, func() { From[string](42) })
+	assertPanicsWith(t, ErrInvalidSource, func() { NewIterator[string](map[string]string{}) })
+	assertPanicsWith(t, ErrInvalidSource, func() { Map[string, int](42, func(string) int { return 0 }) })
+}
+
+func TestErrWrongType(t *testing.T) {
+	arr := []testStruct{{A: "abcde"}, {A: "something"}}
+
+	values, err := TryPluck[testStruct](arr, "A")
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"abcde", "something"}, values)
+
+	values, err = TryPluck[testStruct](arr, "D")
+	assert.Nil(t, values)
+	assert.True(t, errors.Is(err, ErrWrongType))
+
+	_, err = TryPluck[int]([]int{1, 2}, "A")
+	assert.True(t, errors.Is(err, ErrWrongType))
+
+	assertPanicsWith(t, ErrWrongType, func() { Pluck[int]([]int{1, 2}, "A") })
+}
+
+func TestErrIndexOutOfRange(t *testing.T) {
+	val, err := From[string](testArray).TryAt(2)
+	assert.NoError(t, err)
+	assert.Equal(t, "pear", val)
+
+	zero, err := From[int]([]int{0, 1}).TryAt(0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, zero)
+
+	_, err = From[string](testArray).TryAt(len(testArray))
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+
+	_, err = From[string](testArray).TryAt(-1)
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+
+	_, err = FromChannel[int](newTestChannel(1, 2, 3)).Filter(func(x int) bool { return x > 1 }).TryAt(2)
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+}
+
+func assertPanicsWith(t *testing.T, target error, f func()) {
+	t.Helper()
+	defer func() {
+		err, _ := recover().(error)
+		assert.True(t, errors.Is(err, target), "expected a panic with %v, got %v", target, err)
+	}()
+	f()
+}
@@ -1,20 +1,82 @@
 package streams
 
-// From Creates a Stream from a given iterable or IList.  Panics if the value is not an array, slice, map or IIterable
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	defaultThreads int64 = 1
+
+	traceMx     sync.Mutex
+	traceOutput io.Writer = os.Stderr
+)
+
+// SetDefaultThreads sets the amount of go channels used for parallel filtering by the streams that are created without
+// providing the amount of threads. Providing a value <= 0 indicates the maximum amount of available CPUs will be the
+// number that determines the amount of go channels to be used. By default, streams are processed sequentially (1).
+func SetDefaultThreads(n int) {
+	if n <= 0 {
+		n = runtime.NumCPU()
+	}
+	atomic.StoreInt64(&defaultThreads, int64(n))
+}
+
+// DefaultThreads returns the amount of go channels used for parallel filtering by the streams that are created without
+// providing the amount of threads.
+func DefaultThreads() int {
+	return int(atomic.LoadInt64(&defaultThreads))
+}
+
+// SetTraceOutput sets the writer where the elements traced with `IStream.Trace` are written. By default, the traces are
+// written to os.Stderr.
+func SetTraceOutput(w io.Writer) {
+	traceMx.Lock()
+	defer traceMx.Unlock()
+	traceOutput = w
+}
+
+// writeTrace writes an element traced with the provided label to the trace output
+func writeTrace(label string, x any) {
+	traceMx.Lock()
+	defer traceMx.Unlock()
+	_, _ = fmt.Fprintf(traceOutput, "[%s] %v\n", label, x)
+}
+
+// From Creates a Stream from a given iterable or IList.  Panics with ErrInvalidSource if the value is not an array, slice
+// or IIterable. See `TryFrom` for a version which returns the error instead.
 //
 //   - set:      The iterable or IList to be used to create the stream
 //   - threads:  If provided, enables parallel filtering for all filter operations. Indicates the amount of go channels
 //     to be used to a maximum of the available CPUs in the host machine. <= 0 indicates the maximum amount of
 //     available CPUs will be the number that determines the amount of go channels to be used. If order matters,
-//     best combine it with a `SortBy`. Only needs to be provided once per stream.
+//     best combine it with a `SortBy`. Only needs to be provided once per stream. If not provided, the value set
+//     with `SetDefaultThreads` is used.
 func From[T comparable](set any, threads ...int) (ret IStream[T]) {
+	ret, err := TryFrom[T](set, threads...)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
+
+// TryFrom Creates a Stream from a given iterable or IList, the same way as `From`, but returns ErrInvalidSource instead
+// of panicking if the value is not an array, slice or IIterable.
+func TryFrom[T comparable](set any, threads ...int) (IStream[T], error) {
 	switch val := set.(type) {
 	case []T:
-		return FromArray(val, threads...)
+		return FromArray(val, threads...), nil
 	case ICollection[T]:
-		return FromCollection(val, threads...)
+		return FromCollection(val, threads...), nil
+	case IIterable[T]:
+		return FromIterable(val, threads...), nil
 	}
-	panic("invalid source to create a stream")
+	return nil, fmt.Errorf("%w to create a stream: %T", ErrInvalidSource, set)
 }
 
 func FromMap[K comparable, V any](set any, threads ...int) (ret IStream[*KeyValuePair[K, V]]) {
@@ -28,7 +90,31 @@ func FromMap[K comparable, V any](set any, threads ...int) (ret IStream[*KeyValu
 		col := NewMap[K, V](val)
 		return FromCollection[*KeyValuePair[K, V]](col)
 	}
-	panic("invalid source to create a stream")
+	panic(fmt.Errorf("%w to create a stream", ErrInvalidSource))
+}
+
+// ToMapFromPairs processes a stream of `*KeyValuePair[K, V]`, such as the ones created by `FromMap`, and collects the
+// resulting pairs back into a new IMap. If more than one pair shares the same key, the last one processed is kept.
+func ToMapFromPairs[K comparable, V any](s IStream[*KeyValuePair[K, V]]) IMap[K, V] {
+	ret := NewMap[K, V]()
+	s.ForEach(func(pair *KeyValuePair[K, V]) {
+		ret.Set(pair.Key, pair.Value)
+	})
+	return ret
+}
+
+// Unzip processes a stream of `*KeyValuePair[K, V]`, such as the ones created by `FromMap`, and splits the resulting
+// pairs into a list of keys and a list of values in a single pass. Both lists keep the order of the pairs, so the key
+// and the value of every pair share the same index.
+//
+//	Eg:   keys, values := streams.Unzip[string, int](streams.FromMap[string, int](m).Filter(...))
+func Unzip[K, V comparable](s IStream[*KeyValuePair[K, V]]) (keys IList[K], values IList[V]) {
+	keys, values = NewList[K](), NewList[V]()
+	s.ForEach(func(pair *KeyValuePair[K, V]) {
+		_ = keys.Add(pair.Key)
+		_ = values.Add(pair.Value)
+	})
+	return
 }
 
 // FromArray Creates a Stream from a given array.  Panics if the input is not an array or slice.
@@ -50,7 +136,8 @@ func FromArray[T comparable](array []T, threads ...int) IStream[T] {
 //   - threads:  If provided, enables parallel filtering for all filter operations. Indicates the amount of go channels
 //     to be used to a maximum of the available CPUs in the host machine. <= 0 indicates the maximum amount of
 //     available CPUs will be the number that determines the amount of go channels to be used. If order matters,
-//     best combine it with a `SortBy`. Only needs to be provided once per stream.
+//     best combine it with a `SortBy`. Only needs to be provided once per stream. If not provided, the value set
+//     with `SetDefaultThreads` is used.
 func FromCollection[T comparable](iterable ICollection[T], threads ...int) IStream[T] {
 	return &Stream[T]{
 		iterable: iterable,
@@ -58,16 +145,134 @@ func FromCollection[T comparable](iterable ICollection[T], threads ...int) IStre
 	}
 }
 
+// FromIterable Creates a Stream from a given IIterable. If the iterable is not an ICollection, the elements are lazily
+// read from its iterator as the stream is processed, which allows custom iterables without a known size (such as
+// generators) to be used as the source of a stream.
+//
+//   - iterable: The IIterable to be used to create the stream
+//   - threads:  If provided, enables parallel filtering for all filter operations. Indicates the amount of go channels
+//     to be used to a maximum of the available CPUs in the host machine. <= 0 indicates the maximum amount of
+//     available CPUs will be the number that determines the amount of go channels to be used. If order matters,
+//     best combine it with a `SortBy`. Only needs to be provided once per stream.
+func FromIterable[T comparable](iterable IIterable[T], threads ...int) IStream[T] {
+	if col, ok := iterable.(ICollection[T]); ok {
+		return FromCollection[T](col, threads...)
+	}
+	return FromCollection[T](newIterableCollection[T](iterable.Iterator()), threads...)
+}
+
+// FromChannel Creates a Stream which lazily reads its elements from the provided channel. Elements read from the
+// channel are buffered, so the stream can be processed more than once. Terminal operations that require all the elements
+// (such as `Count` or `Last`) will block until the channel is closed.
+//
+//   - ch:       The channel to read elements from
+//   - threads:  If provided, enables parallel filtering for all filter operations. Indicates the amount of go channels
+//     to be used to a maximum of the available CPUs in the host machine. <= 0 indicates the maximum amount of
+//     available CPUs will be the number that determines the amount of go channels to be used. If order matters,
+//     best combine it with a `SortBy`. Only needs to be provided once per stream.
+func FromChannel[T comparable](ch <-chan T, threads ...int) IStream[T] {
+	return FromCollection[T](newIterableCollection[T](newChannelIterator[T](ch)), threads...)
+}
+
+// FromMultiMap Creates a Stream of all the values contained by the slices of the provided map, flattened into a single
+// stream, useful to process data indexed by key. The values of every key are produced in the order of their slice, but
+// the order of the keys follows the iteration order of the map, which is not guaranteed.
+//
+//   - m:        The map whose values are to be used to create the stream
+//   - threads:  If provided, enables parallel filtering for all filter operations. Indicates the amount of go channels
+//     to be used to a maximum of the available CPUs in the host machine. <= 0 indicates the maximum amount of
+//     available CPUs will be the number that determines the amount of go channels to be used. If order matters,
+//     best combine it with a `SortBy`. Only needs to be provided once per stream.
+func FromMultiMap[K comparable, V comparable](m map[K][]V, threads ...int) IStream[V] {
+	size := 0
+	for _, values := range m {
+		size += len(values)
+	}
+
+	ret := make([]V, 0, size)
+	for _, values := range m {
+		ret = append(ret, values...)
+	}
+	return FromArray[V](ret, threads...)
+}
+
+// Generate Creates an infinite Stream whose elements are produced by invoking the provided function, such as sequences
+// or random values. The elements are generated lazily as the stream is processed, so the stream can only be consumed by
+// operations that stop early, such as `Limit`, `ForEachUntil` or `First`. Generated streams are always processed
+// sequentially, since parallel processing requires all the elements of the source.
+//
+//	Eg:   i := 0
+//	      evens := streams.Generate[int](func() int { i++; return i }).
+//	          Filter(func(x int) bool { return x%2 == 0 }).
+//	          Limit(5).
+//	          ToArray()
+func Generate[T comparable](f func() T) IStream[T] {
+	return FromCollection[T](newIterableCollection[T](newFuncIterator[T](func() (T, bool) {
+		return f(), true
+	})), 1)
+}
+
+// FromFunc Creates a Stream which lazily reads its elements by invoking the provided function, which allows sources that
+// may fail while being read (such as readers or database rows) to be used as the source of a stream. Elements read are
+// buffered, so the stream can be processed more than once.
+//
+// The function returns the next element, whether the source produced an element (false ends the stream) and the error
+// produced when reading the element, if any. Errors are reported to the functions registered with `OnError` as soon as
+// the read that produced them completes; the element that failed is skipped and, unless `ok` is false, the stream
+// continues with the next one.
+//
+//   - next:     The function that produces the elements of the stream
+//   - threads:  If provided, enables parallel filtering for all filter operations. Indicates the amount of go channels
+//     to be used to a maximum of the available CPUs in the host machine. <= 0 indicates the maximum amount of
+//     available CPUs will be the number that determines the amount of go channels to be used. If order matters,
+//     best combine it with a `SortBy`. Only needs to be provided once per stream.
+func FromFunc[T comparable](next func() (val T, ok bool, err error), threads ...int) IStream[T] {
+	var mx sync.Mutex
+	var pending []error
+
+	ret := &Stream[T]{threads: getCores(threads...)}
+	col := newIterableCollection[T](newFuncIterator[T](func() (T, bool) {
+		for {
+			val, ok, err := next()
+			if err != nil {
+				mx.Lock()
+				pending = append(pending, err)
+				mx.Unlock()
+				if ok {
+					continue
+				}
+			}
+			return val, ok
+		}
+	}))
+
+	// Errors are reported once the collection releases its lock, so the handlers can use the stream
+	col.onRead = func() {
+		mx.Lock()
+		errs := pending
+		pending = nil
+		mx.Unlock()
+
+		for _, err := range errs {
+			ret.reportError(err)
+		}
+	}
+	ret.iterable = col
+	return ret
+}
+
 // NewList Creates a new empty array collection of the given type
 func NewList[T comparable](arr ...[]T) IList[T] {
-	ret := &arrayCollection[T]{}
-	base := &CollectionBase[T]{}
-	base.SetAbstract(ret)
-	ret.CollectionBase = base
 	if len(arr) > 0 {
-		ret.arr = arr[0]
+		return newArrayCollection[T](arr[0])
 	}
-	return ret
+	return newArrayCollection[T](nil)
+}
+
+// NewListCap Creates a new empty array collection of the given type, preallocating the provided capacity to reduce the
+// reallocations of the array when the final size of the list is roughly known.
+func NewListCap[T comparable](capacity int) IList[T] {
+	return newArrayCollection[T](make([]T, 0, capacity))
 }
 
 // NewMap creates a new map collection of the given type
@@ -85,6 +290,18 @@ func NewMap[K comparable, V any](m ...map[K]V) IMap[K, V] {
 	return ret
 }
 
+// NewOrderedMap creates a new map collection containing the provided pairs, where the keys, the iteration and the result
+// of ToArray strictly follow the order in which the pairs were provided. Map collections keep their keys in insertion
+// order, so keys added afterwards with `Set` or `Add` are appended at the end, while overwriting the value of an existing
+// key keeps its position. Unlike NewMap, whose initial order follows the iteration of the provided Go map, the initial
+// order of an ordered map is always deterministic. If more than one pair shares the same key, the position of the first
+// one and the value of the last one are kept.
+func NewOrderedMap[K comparable, V any](pairs ...*KeyValuePair[K, V]) IMap[K, V] {
+	ret := NewMap[K, V]()
+	_ = ret.Add(pairs...)
+	return ret
+}
+
 // NewIterator creates an iterator of T using the provided source.
 //
 //	{source}  -  The source to read elements from. This function accepts the following sources where T is comparable:
@@ -102,7 +319,7 @@ func NewIterator[T comparable](source any) (ret IIterator[T]) {
 	case IList[T]:
 		return newCollectionIterator[T](src)
 	}
-	panic("invalid source to create a stream")
+	panic(fmt.Errorf("%w to create an iterator", ErrInvalidSource))
 }
 
 // Map maps the elements of the source to a new element, using the mapping function provided. Outputs a new collection
@@ -133,7 +350,110 @@ func Map[From, To comparable](source any, f ConvertFunc[From, To]) IList[To] {
 	case IStream[From]:
 		return mapStream[From, To](src, f)
 	}
-	panic("invalid mapping source")
+	panic(fmt.Errorf("%w for mapping", ErrInvalidSource))
+}
+
+// MapCollectErrors maps the elements of the source using the provided mapping function, which may fail, similar to `Map`.
+// Instead of stopping at the first failure, all the elements are mapped, collecting the successful results in order
+// and the errors of the failed elements by their index in the source, which allows partial successes to be processed.
+// The returned map of errors is nil if no element failed.
+//
+//	{source}  -  The source to read elements from. Accepts the same sources as `Map`
+//	{f}       -  The mapping function
+//
+// panics for any other source type
+func MapCollectErrors[From, To comparable](source any, f func(From) (To, error)) (ret []To, errs map[int]error) {
+	iterator := toIterator[From](source)
+	i := 0
+	for x := iterator.Current(); iterator.HasNext(); x = iterator.Next() {
+		if val, err := f(x); err != nil {
+			if errs == nil {
+				errs = map[int]error{}
+			}
+			errs[i] = err
+		} else {
+			ret = append(ret, val)
+		}
+		i++
+	}
+	return
+}
+
+// MapWhile maps the elements of the source using the provided mapping function, similar to `Map`, stopping at the first
+// element for which the function returns false. The element that stopped the mapping is not included and no further
+// elements are read from the source, so it is suitable for lazy or infinite sources.
+//
+//	{source}  -  The source to read elements from. Accepts the same sources as `Map`
+//	{f}       -  The mapping function, returns false to stop mapping
+//
+// panics for any other source type
+func MapWhile[From, To comparable](source any, f func(From) (To, bool)) IList[To] {
+	ret := NewList[To]()
+	add := func(x From) bool {
+		val, ok := f(x)
+		if ok {
+			_ = ret.Add(val)
+		}
+		return ok
+	}
+
+	if stream, ok := source.(IStream[From]); ok {
+		stream.ForEachUntil(add)
+		return ret
+	}
+
+	iterator := toIterator[From](source)
+	for x := iterator.Current(); iterator.HasNext(); x = iterator.Next() {
+		if !add(x) {
+			break
+		}
+	}
+	return ret
+}
+
+// Into binds the target type of a mapping for the provided stream, returning a handler which allows the stream to be
+// mapped and the resulting stream to continue being chained fluently.
+//
+//	Eg:   streams.Into[int](streams.From[string](strs)).
+//	          Map(func(x string) int { return len(x) }).
+//	          Filter(func(x int) bool { return x > 3 }).
+//	          ToArray()
+func Into[To, From comparable](s IStream[From]) IStreamMapper[From, To] {
+	return &streamMapper[From, To]{stream: s}
+}
+
+// ParallelMapOrdered maps the elements of the source to a new element, using the mapping function provided, similar to `Map`,
+// but the mapping function is executed concurrently by multiple go routines. The output preserves the order of the
+// source, and the amount of elements being mapped or waiting to be released in order is bounded by the `buffer` size,
+// so the memory used by the pipeline does not grow with the size of the source.
+//
+//	{source}   -  The source to read elements from. Accepts the same sources as `Map`
+//	{f}        -  The mapping function
+//	{threads}  -  The amount of go routines used to execute the mapping function. <= 0 indicates the maximum amount of
+//	              available CPUs will be the number that determines the amount of go routines to be used
+//	{buffer}   -  The maximum amount of elements in flight at any time. <= 0 defaults to the amount of threads
+//
+// panics for any other source type
+func ParallelMapOrdered[From, To comparable](source any, f ConvertFunc[From, To], threads, buffer int) IList[To] {
+	return NewList[To](parallelMapOrdered[From, To](toIterator[From](source), f, getCores(threads), buffer))
+}
+
+// ParallelForEachOrdered processes the elements of the stream executing the `work` function concurrently by multiple go
+// routines, while the `consume` function is invoked sequentially with every element and the result of its work, in the
+// order of the stream. Useful when the computation is parallelizable but its side effects must be ordered, such as
+// writing the results sequentially. Completed results are buffered until they can be released in order, where up to
+// `threads` elements are in flight at any time.
+//
+//	{s}        -  The stream to process
+//	{work}     -  The function executed concurrently for every element
+//	{consume}  -  The function invoked sequentially, in order, with every element and the result of its work
+//	{threads}  -  The amount of go routines used to execute the work function. <= 0 indicates the maximum amount of
+//	              available CPUs will be the number that determines the amount of go routines to be used
+//
+//	Eg:   streams.ParallelForEachOrdered[string, []byte](files, render, func(name string, data []byte) { w.Write(data) }, 4)
+func ParallelForEachOrdered[T comparable, R any](s IStream[T], work func(T) R, consume func(T, R), threads int) {
+	cores := getCores(threads)
+	parallelOrdered[T, R](s.ToIterable().Iterator(), work, cores, cores, consume)
 }
 
 // MapNonComparable is similar to Map, maps the elements of the source to a new element, using the mapping function
@@ -156,8 +476,24 @@ func MapNonComparable[From, To any](source any, f ConvertFunc[From, To]) []To {
 	case IIterable[From]:
 		return mapIterable[From, To](src, f)
 	case IIterator[From]:
+		return mapIterator[From, To](src, f)
 	}
-	panic("invalid mapping source")
+	panic(fmt.Errorf("%w for mapping", ErrInvalidSource))
+}
+
+// FlatMapNonComparable is similar to MapNonComparable, but the mapping function produces a slice of elements for every
+// element of the source, and the resulting slices are flattened into a single array, in order. The source and the
+// output accept non-comparable types, such as structs containing slices.
+//
+//	{source}  -  The source to read elements from. Accepts the same sources as `MapNonComparable`
+//	{f}       -  The mapping function, producing the elements for every element of the source
+//
+// panics for any other source type
+func FlatMapNonComparable[From, To any](source any, f func(From) []To) (ret []To) {
+	for _, arr := range MapNonComparable[From, []To](source, f) {
+		ret = append(ret, arr...)
+	}
+	return
 }
 
 // MapToPtr converts a collection of T to a collection of *T. Many structs are not `comparable` which makes T unsupported
@@ -181,6 +517,47 @@ func MapToPtr[T any](source any) []*T {
 		})
 }
 
+// Pluck extracts the value of the named field from every struct (or pointer to struct) of the source, using
+// reflection. Useful for quick projections without writing a mapping function. Panics with ErrWrongType if an element
+// is not a struct or the struct does not contain an exported field with the provided name. See `TryPluck` for a
+// version which returns the error instead.
+//
+//	{source}  -  The source to read elements from. Accepts the same sources as `MapNonComparable`
+//	{field}   -  The name of the field to extract
+func Pluck[T any](source any, field string) []any {
+	ret, err := TryPluck[T](source, field)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
+
+// TryPluck extracts the value of the named field from every struct (or pointer to struct) of the source, the same way
+// as `Pluck`, but returns ErrWrongType instead of panicking if an element is not a struct or the struct does not
+// contain an exported field with the provided name.
+func TryPluck[T any](source any, field string) (ret []any, err error) {
+	ret = MapNonComparable[T, any](source, func(x T) any {
+		if err != nil {
+			return nil
+		}
+		v := reflect.Indirect(reflect.ValueOf(x))
+		if v.Kind() != reflect.Struct {
+			err = fmt.Errorf("%w, unable to pluck field %s, %T is not a struct", ErrWrongType, field, x)
+			return nil
+		}
+		f := v.FieldByName(field)
+		if !f.IsValid() || !f.CanInterface() {
+			err = fmt.Errorf("%w, unable to pluck field %s, not found in %T", ErrWrongType, field, x)
+			return nil
+		}
+		return f.Interface()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
 // Mappers returns a handler which providers predefined ConvertFunc mappers for different value types that can be used
 // mapping functions such as `Map[F, T]` and `MapNonComparable[F, T]`
 func Mappers() IMappers {
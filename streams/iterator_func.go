@@ -0,0 +1,73 @@
+package streams
+
+var (
+	_ IIterator[string] = (*funcIterator[string])(nil)
+)
+
+// funcIterator is an iterator which obtains its elements by invoking a function, which returns the next element and
+// whether the element exists. Used to iterate over sources without an index, such as channels or generators.
+type funcIterator[T any] struct {
+	next    func() (T, bool)
+	current T
+	ok      bool
+	started bool
+}
+
+func (iter *funcIterator[T]) Current() T {
+	iter.start()
+	return iter.current
+}
+
+func (iter *funcIterator[T]) MoveNext() bool {
+	if !iter.HasNext() {
+		return false
+	}
+
+	iter.current, iter.ok = iter.next()
+	return iter.ok
+}
+
+func (iter *funcIterator[T]) HasNext() bool {
+	iter.start()
+	return iter.ok
+}
+
+func (iter *funcIterator[T]) Next() (ret T) {
+	if !iter.MoveNext() {
+		return
+	}
+
+	return iter.current
+}
+
+func (iter *funcIterator[T]) Skip(n int) IIterator[T] {
+	for i := 0; i < n && iter.MoveNext(); i++ {
+	}
+	return iter
+}
+
+func (iter *funcIterator[T]) ForEachRemaining(f IterFunc[T]) {
+	for val := iter.Current(); iter.HasNext(); val = iter.Next() {
+		f(val)
+	}
+}
+
+// start obtains the first element the first time the iterator is accessed, so creating the iterator does not block
+func (iter *funcIterator[T]) start() {
+	if iter.started {
+		return
+	}
+	iter.started = true
+	iter.current, iter.ok = iter.next()
+}
+
+func newFuncIterator[T any](next func() (T, bool)) IIterator[T] {
+	return &funcIterator[T]{next: next}
+}
+
+func newChannelIterator[T any](ch <-chan T) IIterator[T] {
+	return newFuncIterator[T](func() (ret T, ok bool) {
+		ret, ok = <-ch
+		return
+	})
+}
@@ -1,6 +1,10 @@
 package streams
 
-import "strconv"
+import (
+	"fmt"
+	"strconv"
+	"sync"
+)
 
 /** This file provides a few predefined mappers that can be used with the steams.Map **/
 
@@ -39,6 +43,14 @@ func (mappers) StringToInt(errorHandler ...func(string, error)) ConvertFunc[stri
 	}
 }
 
+type streamMapper[From, To comparable] struct {
+	stream IStream[From]
+}
+
+func (m *streamMapper[From, To]) Map(f ConvertFunc[From, To]) IStream[To] {
+	return mapStream[From, To](m.stream, f).Stream()
+}
+
 func mapStream[From, To comparable](from IStream[From], f ConvertFunc[From, To]) IList[To] {
 	return NewList[To](mapIterable[From, To](from.ToCollection(), f))
 }
@@ -55,3 +67,89 @@ func mapIterator[From, To any](from IIterator[From], f ConvertFunc[From, To]) (r
 
 	return
 }
+
+type indexedValue[T any] struct {
+	index int
+	value T
+}
+
+type indexedResult[From, To any] struct {
+	index  int
+	source From
+	value  To
+}
+
+func parallelMapOrdered[From, To any](from IIterator[From], f ConvertFunc[From, To], threads, buffer int) (ret []To) {
+	parallelOrdered[From, To](from, f, threads, buffer, func(_ From, val To) {
+		ret = append(ret, val)
+	})
+	return
+}
+
+// parallelOrdered executes the provided function concurrently over the elements of the iterator, invoking `emit` with
+// every element and its result sequentially, in the order of the source, as soon as the results become available.
+func parallelOrdered[From, To any](from IIterator[From], f ConvertFunc[From, To], threads, buffer int, emit func(From, To)) {
+	if buffer <= 0 {
+		buffer = threads
+	}
+
+	var wg sync.WaitGroup
+	jobs := make(chan indexedValue[From], buffer)
+	results := make(chan indexedResult[From, To], buffer)
+
+	// Every element read from the source takes a token which is only released once the element is emitted in order, so
+	// no more than `buffer` elements are in flight or waiting in the reorder buffer.
+	tokens := make(chan struct{}, buffer)
+
+	go func() {
+		defer close(jobs)
+		i := 0
+		for val := from.Current(); from.HasNext(); val = from.Next() {
+			tokens <- struct{}{}
+			jobs <- indexedValue[From]{index: i, value: val}
+			i++
+		}
+	}()
+
+	wg.Add(threads)
+	for i := 0; i < threads; i++ {
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				results <- indexedResult[From, To]{index: job.index, source: job.value, value: f(job.value)}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	pending := map[int]indexedResult[From, To]{}
+	next := 0
+
+	for res := range results {
+		pending[res.index] = res
+		for val, ok := pending[next]; ok; val, ok = pending[next] {
+			emit(val.source, val.value)
+			delete(pending, next)
+			next++
+			<-tokens
+		}
+	}
+}
+
+func toIterator[T comparable](source any) IIterator[T] {
+	switch src := source.(type) {
+	case []T:
+		return newArrayIterator[T](src)
+	case IIterable[T]:
+		return src.Iterator()
+	case IIterator[T]:
+		return src
+	case IStream[T]:
+		return src.ToIterable().Iterator()
+	}
+	panic(fmt.Errorf("%w for mapping", ErrInvalidSource))
+}
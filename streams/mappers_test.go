@@ -0,0 +1,168 @@
+package streams
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallelMapOrdered(t *testing.T) {
+	arr := make([]int, 500)
+	expected := make([]string, len(arr))
+	for i := range arr {
+		arr[i] = i
+		expected[i] = strconv.Itoa(i)
+	}
+
+	mapper := func(x int) string {
+		time.Sleep(time.Duration(rand.Intn(100)) * time.Microsecond)
+		return strconv.Itoa(x)
+	}
+
+	assert.Equal(t, expected, ParallelMapOrdered[int, string](arr, mapper, 8, 16).ToArray())
+	assert.Equal(t, expected, ParallelMapOrdered[int, string](From[int](arr), mapper, -1, 0).ToArray())
+	assert.Equal(t, 0, ParallelMapOrdered[int, string]([]int{}, mapper, 4, 4).Len())
+}
+
+func BenchmarkParallelMapOrdered(b *testing.B) {
+	arr := make([]int, 200)
+	for i := range arr {
+		arr[i] = i
+	}
+
+	slowMapper := func(x int) string {
+		time.Sleep(50 * time.Microsecond)
+		return strconv.Itoa(x)
+	}
+
+	b.Run("Map", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Map[int, string](arr, slowMapper)
+		}
+	})
+
+	b.Run("ParallelMapOrdered", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ParallelMapOrdered[int, string](arr, slowMapper, 8, 16)
+		}
+	})
+}
+
+func TestInto(t *testing.T) {
+	result := Into[int](From[string](testArray)).
+		Map(func(x string) int { return len(x) }).
+		Filter(func(x int) bool { return x > 4 }).
+		Sort(ComparableFn[int]()).
+		ToArray()
+
+	assert.Equal(t, []int{5, 5, 6, 6, 9}, result)
+}
+
+func TestParallelForEachOrdered(t *testing.T) {
+	arr := make([]int, 200)
+	for i := range arr {
+		arr[i] = i
+	}
+
+	var order []int
+	var results []string
+
+	ParallelForEachOrdered[int, string](From[int](arr), func(x int) string {
+		// Elements finish out of order
+		time.Sleep(time.Duration(rand.Intn(200)) * time.Microsecond)
+		return strconv.Itoa(x * 2)
+	}, func(x int, r string) {
+		order = append(order, x)
+		results = append(results, r)
+	}, 8)
+
+	assert.Equal(t, arr, order)
+	for i, r := range results {
+		assert.Equal(t, strconv.Itoa(i*2), r)
+	}
+}
+
+func TestMapCollectErrors(t *testing.T) {
+	source := []string{"1", "two", "3", "", "5"}
+
+	result, errs := MapCollectErrors[string, int](source, strconv.Atoi)
+	assert.Equal(t, []int{1, 3, 5}, result)
+	assert.Len(t, errs, 2)
+	assert.Error(t, errs[1])
+	assert.Error(t, errs[3])
+
+	result, errs = MapCollectErrors[string, int](From[string]([]string{"7", "8"}), strconv.Atoi)
+	assert.Equal(t, []int{7, 8}, result)
+	assert.Nil(t, errs)
+}
+
+func TestMapWhile(t *testing.T) {
+	parse := func(x string) (int, bool) {
+		i, err := strconv.Atoi(x)
+		return i, err == nil
+	}
+
+	source := []string{"1", "2", "three", "4"}
+	assert.Equal(t, []int{1, 2}, MapWhile[string, int](source, parse).ToArray())
+	assert.Equal(t, []int{1, 2}, MapWhile[string, int](From[string](source), parse).ToArray())
+	assert.Equal(t, 0, MapWhile[string, int]([]string{"one", "2"}, parse).Len())
+
+	// No further elements are read from lazy sources
+	i := 0
+	generated := Generate[string](func() string {
+		i++
+		if i > 3 {
+			return "stop"
+		}
+		return strconv.Itoa(i)
+	})
+	assert.Equal(t, []int{1, 2, 3}, MapWhile[string, int](generated, parse).ToArray())
+	assert.Equal(t, 4, i)
+}
+
+func TestFlatMapNonComparable(t *testing.T) {
+	type order struct {
+		Id    int
+		Items []string
+	}
+	type line struct {
+		OrderId int
+		Item    []string
+	}
+
+	orders := []order{
+		{Id: 1, Items: []string{"apple", "pear"}},
+		{Id: 2, Items: nil},
+		{Id: 3, Items: []string{"kiwi"}},
+	}
+
+	result := FlatMapNonComparable[order, line](orders, func(o order) []line {
+		var ret []line
+		for _, item := range o.Items {
+			ret = append(ret, line{OrderId: o.Id, Item: []string{item}})
+		}
+		return ret
+	})
+
+	assert.Equal(t, []line{
+		{OrderId: 1, Item: []string{"apple"}},
+		{OrderId: 1, Item: []string{"pear"}},
+		{OrderId: 3, Item: []string{"kiwi"}},
+	}, result)
+}
+
+func TestPluck(t *testing.T) {
+	arr := []testStruct{
+		{A: "abcde", B: 1234, C: "zxcv"},
+		{A: "something", B: 54321, C: "something_else"},
+	}
+
+	assert.Equal(t, []any{"abcde", "something"}, Pluck[testStruct](arr, "A"))
+	assert.Equal(t, []any{1234, 54321}, Pluck[*testStruct](MapToPtr[testStruct](arr), "B"))
+
+	assert.Panics(t, func() { Pluck[testStruct](arr, "D") })
+	assert.Panics(t, func() { Pluck[int]([]int{1, 2}, "A") })
+}
@@ -0,0 +1,263 @@
+package streams
+
+import (
+	"container/heap"
+	"runtime"
+	"sync"
+)
+
+/**
+This file provides package level intermediate operations for streams which require additional generic types. Golang
+generics do not allow passing generics to functions that are part of a structure or interface, so these operations
+could not be a part of IStream.
+**/
+
+// DistinctBy appends a filter to the stream which keeps only the first element found for every unique key produced by
+// the provided key function. Useful to dedup elements which are not unique as a whole (such as pointers to structs) by
+// one of their fields.
+//
+//	Eg:   streams.DistinctBy[*Person, string](streams.From[*Person](people), func(p *Person) string { return p.Email })
+//
+// Streams using DistinctBy are always processed sequentially, so the first element of every key is the one kept.
+func DistinctBy[T comparable, K comparable](s IStream[T], keyFn func(T) K) IStream[T] {
+	return filterStateful(s, func() ConditionalFunc[T] {
+		seen := map[K]struct{}{}
+		return func(x T) bool {
+			k := keyFn(x)
+			if _, ok := seen[k]; ok {
+				return false
+			}
+			seen[k] = struct{}{}
+			return true
+		}
+	})
+}
+
+// MergeSorted merges multiple streams which are already sorted by the provided SortFunc into a single sorted stream,
+// without sorting all the elements again (k-way merge). Useful to merge sorted shards or files. Elements considered
+// equal by the SortFunc are produced in the order of the streams provided. The streams are processed lazily, once the
+// resulting stream is processed.
+//
+// NOTE: The order of the streams is not verified. If any of the streams is not sorted, the result will not be sorted
+// either.
+func MergeSorted[T comparable](cmp SortFunc[T], streams ...IStream[T]) IStream[T] {
+	var h *mergeHeap[T]
+
+	next := func() (ret T, ok bool) {
+		if h == nil {
+			h = &mergeHeap[T]{cmp: cmp}
+			for i, s := range streams {
+				iterator := s.ToIterable().Iterator()
+				if iterator.HasNext() {
+					h.items = append(h.items, &mergeItem[T]{iterator: iterator, value: iterator.Current(), index: i})
+				}
+			}
+			heap.Init(h)
+		}
+
+		if h.Len() == 0 {
+			return
+		}
+
+		item := h.items[0]
+		ret, ok = item.value, true
+
+		item.iterator.MoveNext()
+		if item.iterator.HasNext() {
+			item.value = item.iterator.Current()
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+		return
+	}
+
+	return FromCollection[T](newIterableCollection[T](newFuncIterator[T](next)))
+}
+
+// Merge combines multiple streams into a single stream, reading the streams concurrently and producing their elements
+// as soon as they are available, which is useful to fan in several lazy sources such as channels. Unlike concatenating
+// the streams, the order of the elements is not guaranteed. The streams are processed lazily, once the resulting stream
+// is processed.
+//
+// The streams are read by go routines which stop once all the elements are read. If the resulting stream is abandoned
+// before that (such as after `First` or `Limit`), the go routines stop once the resulting stream is garbage collected.
+func Merge[T comparable](streams ...IStream[T]) IStream[T] {
+	m := &merger[T]{streams: streams, done: make(chan struct{})}
+	runtime.SetFinalizer(m, (*merger[T]).stop)
+	return FromCollection[T](newIterableCollection[T](newFuncIterator[T](m.next)))
+}
+
+// FilterKeys appends a filter to a stream of key-value pairs, such as the ones created by `FromMap`, where any pair whose
+// key does not meet the condition provided by the function (returns false) will be filtered.
+func FilterKeys[K comparable, V any](s IStream[*KeyValuePair[K, V]], f func(K) bool) IStream[*KeyValuePair[K, V]] {
+	return s.Filter(func(pair *KeyValuePair[K, V]) bool {
+		return f(pair.Key)
+	})
+}
+
+// FilterValues appends a filter to a stream of key-value pairs, such as the ones created by `FromMap`, where any pair
+// whose value does not meet the condition provided by the function (returns false) will be filtered.
+func FilterValues[K comparable, V any](s IStream[*KeyValuePair[K, V]], f func(V) bool) IStream[*KeyValuePair[K, V]] {
+	return s.Filter(func(pair *KeyValuePair[K, V]) bool {
+		return f(pair.Value)
+	})
+}
+
+// SortByKey sorts a stream of key-value pairs, such as the ones created by `FromMap`, comparing the keys of the pairs
+// with the provided function.
+//
+// - desc:  indicates whether the sorting should be done descendant
+func SortByKey[K comparable, V any](s IStream[*KeyValuePair[K, V]], cmp SortFunc[K], desc ...bool) IStream[*KeyValuePair[K, V]] {
+	return s.Sort(func(a, b *KeyValuePair[K, V]) int {
+		return cmp(a.Key, b.Key)
+	}, desc...)
+}
+
+// SortByValue sorts a stream of key-value pairs, such as the ones created by `FromMap`, comparing the values of the pairs
+// with the provided function.
+//
+// - desc:  indicates whether the sorting should be done descendant
+func SortByValue[K comparable, V any](s IStream[*KeyValuePair[K, V]], cmp func(V, V) int, desc ...bool) IStream[*KeyValuePair[K, V]] {
+	return s.Sort(func(a, b *KeyValuePair[K, V]) int {
+		return cmp(a.Value, b.Value)
+	}, desc...)
+}
+
+// DistinctByKey appends a filter to a stream of key-value pairs, such as the ones created by `FromMap`, which keeps only
+// the first pair found for every key. Unlike `Distinct`, which compares the pairs by reference, the pairs are compared
+// by their key only, regardless of their values. Equivalent to `DistinctBy` using the key of the pairs.
+func DistinctByKey[K comparable, V any](s IStream[*KeyValuePair[K, V]]) IStream[*KeyValuePair[K, V]] {
+	return DistinctBy[*KeyValuePair[K, V], K](s, func(p *KeyValuePair[K, V]) K {
+		return p.Key
+	})
+}
+
+// Ungroup flattens a grouped structure, such as the one produced by collecting `GroupToLists` into a map, back into a
+// flat stream. The elements of every group are produced in the order of the keys of the map, followed by the order of
+// the elements within the group. This is the inverse of grouping, useful to continue the pipeline after per-group
+// transformations.
+//
+//	Eg:   streams.Ungroup[string, *Sale](salesPerRegion).Filter(...)
+func Ungroup[K comparable, V comparable](m IMap[K, IList[V]], threads ...int) IStream[V] {
+	ret := NewList[V]()
+	for _, k := range m.Keys() {
+		if group, ok := m.Get(k); ok && group != nil {
+			_ = ret.Add(group.ToArray()...)
+		}
+	}
+	return FromCollection[V](ret, threads...)
+}
+
+// FlattenOptionals processes a stream of optionals, such as the result of a mapping that may not produce a value for
+// every element, and produces a stream of the values of the present optionals, dropping the empty ones.
+//
+//	Eg:   streams.FlattenOptionals[int](streams.Into[streams.Optional[int]](stream).Map(parse)).ToArray()
+func FlattenOptionals[T comparable](s IStream[Optional[T]]) IStream[T] {
+	var ret []T
+	s.ForEach(func(o Optional[T]) {
+		if val, ok := o.Get(); ok {
+			ret = append(ret, val)
+		}
+	})
+	return FromArray[T](ret)
+}
+
+// filterStateful stages a stateful filter in the provided stream. If the stream is not the default implementation,
+// the stream is processed and the filter is applied immediately.
+func filterStateful[T comparable](s IStream[T], factory func() ConditionalFunc[T]) IStream[T] {
+	if stream, ok := s.(*Stream[T]); ok {
+		return stream.filterStateful(factory)
+	}
+
+	var ret []T
+	f := factory()
+	s.ForEach(func(x T) {
+		if f(x) {
+			ret = append(ret, x)
+		}
+	})
+	return FromArray(ret)
+}
+
+// merger reads the streams merged by `Merge` concurrently. The go routines reading the streams do not reference the
+// merger, so it can be garbage collected once the merged stream is abandoned, which stops them.
+type merger[T comparable] struct {
+	streams []IStream[T]
+	ch      chan T
+	done    chan struct{}
+}
+
+func (m *merger[T]) next() (ret T, ok bool) {
+	if m.ch == nil {
+		m.ch = make(chan T)
+		mergeStreams(m.streams, m.ch, m.done)
+	}
+
+	ret, ok = <-m.ch
+	return
+}
+
+// stop signals the go routines reading the streams to stop, once the merged stream is no longer used
+func (m *merger[T]) stop() {
+	close(m.done)
+}
+
+// mergeStreams sends the elements of the streams to the provided channel until they are all read or done is closed,
+// closing the channel afterwards.
+func mergeStreams[T comparable](streams []IStream[T], ch chan<- T, done <-chan struct{}) {
+	var wg sync.WaitGroup
+	wg.Add(len(streams))
+	for _, s := range streams {
+		go func(s IStream[T]) {
+			defer wg.Done()
+			s.ForEachUntil(func(x T) bool {
+				select {
+				case ch <- x:
+					return true
+				case <-done:
+					return false
+				}
+			})
+		}(s)
+	}
+	go func() {
+		wg.Wait()
+		close(ch)
+	}()
+}
+
+type mergeItem[T comparable] struct {
+	iterator IIterator[T]
+	value    T
+	index    int
+}
+
+// mergeHeap implements heap.Interface holding the current element of every stream being merged
+type mergeHeap[T comparable] struct {
+	items []*mergeItem[T]
+	cmp   SortFunc[T]
+}
+
+func (h *mergeHeap[T]) Len() int {
+	return len(h.items)
+}
+
+func (h *mergeHeap[T]) Less(i, j int) bool {
+	val := h.cmp(h.items[i].value, h.items[j].value)
+	return val < 0 || (val == 0 && h.items[i].index < h.items[j].index)
+}
+
+func (h *mergeHeap[T]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *mergeHeap[T]) Push(x any) {
+	h.items = append(h.items, x.(*mergeItem[T]))
+}
+
+func (h *mergeHeap[T]) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
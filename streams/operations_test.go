@@ -0,0 +1,172 @@
+package streams
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPerson struct {
+	Name  string
+	Email string
+	Age   int
+}
+
+var testPeople = []testPerson{
+	{Name: "John", Email: "john@example.com", Age: 30},
+	{Name: "Jane", Email: "jane@example.com", Age: 25},
+	{Name: "Johnny", Email: "john@example.com", Age: 31},
+	{Name: "Mary", Email: "mary@example.org", Age: 40},
+	{Name: "J.", Email: "jane@example.com", Age: 26},
+}
+
+func TestDistinctBy(t *testing.T) {
+	people := MapToPtr[testPerson](testPeople)
+
+	stream := DistinctBy[*testPerson, string](From[*testPerson](people), func(p *testPerson) string {
+		return p.Email
+	})
+
+	result := Map[*testPerson, string](stream, func(p *testPerson) string { return p.Name }).ToArray()
+	assert.Equal(t, []string{"John", "Jane", "Mary"}, result)
+
+	// The stream can be processed again, since the filter state is reset on every run
+	assert.Equal(t, 3, stream.Count())
+
+	parallel := DistinctBy[*testPerson, string](From[*testPerson](people, 4), func(p *testPerson) string {
+		return p.Email
+	})
+	assert.Equal(t, "John", parallel.First().Name)
+	assert.Equal(t, 3, parallel.Count())
+}
+
+func TestMergeSorted(t *testing.T) {
+	a := From[int]([]int{1, 4, 6, 9, 12})
+	b := From[int]([]int{2, 3, 6, 10})
+	c := FromChannel[int](newTestChannel(0, 5, 11))
+
+	result := MergeSorted[int](ComparableFn[int](), a, b, c).ToArray()
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 6, 9, 10, 11, 12}, result)
+
+	desc := MergeSorted[int](ComparableFn[int](true),
+		From[int]([]int{5, 3, 1}),
+		From[int]([]int{6, 4, 2}).Filter(func(x int) bool { return x != 4 }))
+	assert.Equal(t, []int{6, 5, 3, 2, 1}, desc.ToArray())
+
+	assert.Empty(t, MergeSorted[int](ComparableFn[int]()).ToArray())
+}
+
+func TestMerge(t *testing.T) {
+	a := FromChannel[int](newTestChannel(1, 3, 5, 7, 9))
+	b := FromChannel[int](newTestChannel(2, 4, 6, 8))
+
+	merged := Merge[int](a, b)
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, merged.ToArray())
+
+	// The merged elements are buffered, so the stream can be processed more than once
+	assert.Equal(t, 9, merged.Count())
+
+	// The order of every source is kept within the merged stream
+	var odds []int
+	merged.Filter(func(x int) bool { return x%2 == 1 }).ForEach(func(x int) {
+		odds = append(odds, x)
+	})
+	assert.Equal(t, []int{1, 3, 5, 7, 9}, odds)
+
+	assert.Empty(t, Merge[int]().ToArray())
+}
+
+func TestMerge_Abandoned(t *testing.T) {
+	arr := make([]int, 100)
+	for i := range arr {
+		arr[i] = i
+	}
+
+	before := runtime.NumGoroutine()
+	for i := 0; i < 50; i++ {
+		x := Merge[int](From[int](arr), From[int](arr)).First()
+		assert.Less(t, x, 100)
+	}
+
+	// The go routines reading the streams stop once the merged streams are garbage collected
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), before)
+}
+
+func TestMapStreamHelpers(t *testing.T) {
+	m := map[string]int{"apple": 5, "banana": 2, "kiwi": 9, "avocado": 1, "pear": 4}
+
+	filtered := FilterKeys[string, int](FromMap[string, int](m), func(k string) bool {
+		return strings.HasPrefix(k, "a")
+	})
+	assert.Equal(t, map[string]int{"apple": 5, "avocado": 1}, ToMapFromPairs[string, int](filtered).ToMap())
+
+	sorted := SortByValue[string, int](
+		FilterValues[string, int](FromMap[string, int](m), func(v int) bool { return v > 1 }),
+		ComparableFn[int](), true)
+	keys := Map[*KeyValuePair[string, int], string](sorted, func(p *KeyValuePair[string, int]) string { return p.Key })
+	assert.Equal(t, []string{"kiwi", "apple", "pear", "banana"}, keys.ToArray())
+
+	byKey := SortByKey[string, int](FromMap[string, int](m), strings.Compare)
+	assert.Equal(t, "apple", byKey.First().Key)
+	assert.Equal(t, "pear", byKey.Last().Key)
+}
+
+func TestDistinctByKey(t *testing.T) {
+	pairs := []*KeyValuePair[string, int]{
+		{Key: "apple", Value: 1},
+		{Key: "pear", Value: 2},
+		{Key: "apple", Value: 3},
+	}
+
+	// Distinct compares the pairs by reference, so pairs sharing a key are kept
+	assert.Len(t, From[*KeyValuePair[string, int]](pairs).Distinct().ToArray(), 3)
+
+	result := DistinctByKey[string, int](From[*KeyValuePair[string, int]](pairs)).ToArray()
+	assert.Equal(t, []*KeyValuePair[string, int]{pairs[0], pairs[1]}, result)
+	assert.Equal(t, map[string]int{"apple": 1, "pear": 2}, ToMapFromPairs[string, int](DistinctByKey[string, int](From[*KeyValuePair[string, int]](pairs))).ToMap())
+}
+
+func TestUngroup(t *testing.T) {
+	groups := NewMap[int, IList[string]]()
+	for length, words := range GroupToLists[string, int](From[string](testArray), func(x string) int { return len(x) }) {
+		groups.Set(length, NewList[string](words))
+	}
+
+	result := Ungroup[int, string](groups).ToArray()
+	assert.ElementsMatch(t, testArray, result)
+
+	ordered := NewOrderedMap[string, IList[int]](
+		&KeyValuePair[string, IList[int]]{Key: "odd", Value: NewList[int]([]int{1, 3})},
+		&KeyValuePair[string, IList[int]]{Key: "even", Value: NewList[int]([]int{2, 4})},
+	)
+	assert.Equal(t, []int{1, 3, 2, 4}, Ungroup[string, int](ordered).ToArray())
+}
+
+func TestFlattenOptionals(t *testing.T) {
+	parse := func(x string) Optional[int] {
+		if i, err := strconv.Atoi(x); err == nil {
+			return OptionalOf(i)
+		}
+		return EmptyOptional[int]()
+	}
+
+	optionals := Into[Optional[int]](From[string]([]string{"1", "two", "3", "", "5"})).Map(parse)
+	assert.Equal(t, []int{1, 3, 5}, FlattenOptionals[int](optionals).ToArray())
+	assert.Empty(t, FlattenOptionals[int](From[Optional[int]]([]Optional[int]{EmptyOptional[int]()})).ToArray())
+
+	val, ok := OptionalOf(0).Get()
+	assert.True(t, ok)
+	assert.Equal(t, 0, val)
+	assert.False(t, EmptyOptional[int]().IsPresent())
+	assert.Equal(t, 7, EmptyOptional[int]().OrElse(7))
+	assert.Equal(t, 2, OptionalOf(2).OrElse(7))
+}
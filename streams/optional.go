@@ -0,0 +1,29 @@
+package streams
+
+// OptionalOf creates an Optional which contains the provided value
+func OptionalOf[T comparable](value T) Optional[T] {
+	return Optional[T]{value: value, present: true}
+}
+
+// EmptyOptional creates an Optional which contains no value
+func EmptyOptional[T comparable]() Optional[T] {
+	return Optional[T]{}
+}
+
+// Get returns the value of the optional and whether the value is present
+func (o Optional[T]) Get() (val T, present bool) {
+	return o.value, o.present
+}
+
+// IsPresent indicates whether the optional contains a value
+func (o Optional[T]) IsPresent() bool {
+	return o.present
+}
+
+// OrElse returns the value of the optional if present, otherwise returns the provided default value
+func (o Optional[T]) OrElse(defaultValue T) T {
+	if o.present {
+		return o.value
+	}
+	return defaultValue
+}
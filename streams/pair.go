@@ -0,0 +1,16 @@
+package streams
+
+import "fmt"
+
+// NewPair creates a new key-value pair, which is cleaner than a struct literal when building pairs for `FromMap` or
+// `NewOrderedMap`.
+//
+//	Eg:   streams.NewOrderedMap[string, int](streams.NewPair("apple", 1), streams.NewPair("pear", 2))
+func NewPair[K comparable, V any](k K, v V) *KeyValuePair[K, V] {
+	return &KeyValuePair[K, V]{Key: k, Value: v}
+}
+
+// String returns the string representation of the pair, in the format used by Go to print the entries of a map
+func (p *KeyValuePair[K, V]) String() string {
+	return fmt.Sprintf("%v:%v", p.Key, p.Value)
+}
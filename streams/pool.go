@@ -0,0 +1,61 @@
+package streams
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	bufferPooling int32
+	bufferPools   sync.Map
+)
+
+// SetBufferPooling enables or disables the reuse of the buffers used for the intermediate results of the streams, such
+// as the result of the filters before sorting, to reduce the pressure on the garbage collector in pipelines which run
+// streams repeatedly. The buffers are only reused once the terminal operation that produced them no longer needs them,
+// such as `ForEach`; terminal operations which return the results (such as `ToArray`) leave the buffers to the caller.
+// Disabled by default.
+func SetBufferPooling(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&bufferPooling, v)
+}
+
+// bufferPool returns the pool of buffers of T
+func bufferPool[T any]() *sync.Pool {
+	key := reflect.TypeOf((*T)(nil))
+	if pool, ok := bufferPools.Load(key); ok {
+		return pool.(*sync.Pool)
+	}
+	pool, _ := bufferPools.LoadOrStore(key, &sync.Pool{})
+	return pool.(*sync.Pool)
+}
+
+// getBuffer returns an empty buffer from the pool, which may be nil if the pool is empty. Returns `ok=false` if buffer
+// pooling is disabled.
+func getBuffer[T any]() (ret []T, ok bool) {
+	if atomic.LoadInt32(&bufferPooling) == 0 {
+		return nil, false
+	}
+	if buf, ok := bufferPool[T]().Get().(*[]T); ok {
+		ret = (*buf)[:0]
+	}
+	return ret, true
+}
+
+// putBuffer returns the buffer to the pool. The elements of the buffer are cleared so the pool does not keep them alive.
+func putBuffer[T any](buf []T) {
+	if cap(buf) == 0 || atomic.LoadInt32(&bufferPooling) == 0 {
+		return
+	}
+	var zero T
+	buf = buf[:cap(buf)]
+	for i := range buf {
+		buf[i] = zero
+	}
+	buf = buf[:0]
+	bufferPool[T]().Put(&buf)
+}
@@ -14,6 +14,13 @@ func NewSet[T comparable]() ISet[T] {
 	return &set[T]{m: map[T]struct{}{}}
 }
 
+// NewIdentitySet creates a new set of pointers to T, where membership is determined by pointer identity. Useful to
+// dedup streams of structs which are not comparable, such as the ones obtained with `MapToPtr`: two pointers are the
+// same element only if they point to the same value, regardless of the contents of the values they point to.
+func NewIdentitySet[T any]() ISet[*T] {
+	return NewSet[*T]()
+}
+
 type set[T comparable] struct {
 	m  map[T]struct{}
 	mx sync.RWMutex
@@ -126,6 +133,7 @@ func (c *set[T]) ToArray() (ret []T) {
 	c.mx.RLock()
 	defer c.mx.RUnlock()
 
+	ret = make([]T, 0, len(c.m))
 	for item := range c.m {
 		ret = append(ret, item)
 	}
@@ -135,3 +143,11 @@ func (c *set[T]) ToArray() (ret []T) {
 func (c *set[T]) IsEmpty() bool {
 	return len(c.m) == 0
 }
+
+func (c *set[T]) Equals(other ICollection[T]) bool {
+	return collectionEquals[T](c, other)
+}
+
+func (c *set[T]) EqualsUnordered(other ICollection[T]) bool {
+	return collectionEqualsUnordered[T](c, other)
+}
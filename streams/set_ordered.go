@@ -0,0 +1,271 @@
+package streams
+
+import (
+	"sync"
+)
+
+var (
+	// To ensure *orderedSet implements ISet and IList on build
+	_ ISet[string]        = (*orderedSet[string])(nil)
+	_ IList[string]       = (*orderedSet[string])(nil)
+	_ ICollection[string] = (*orderedSet[string])(nil)
+)
+
+// NewOrderedSet creates a new set which keeps track of the order in which the elements were inserted, so iterating over
+// the set or calling ToArray always produces the elements in insertion order, unlike the set created by NewSet whose
+// order is not deterministic. Membership checks remain O(1), removals are O(n) since the order of the remaining elements
+// has to be preserved.
+func NewOrderedSet[T comparable]() ISet[T] {
+	return newOrderedSet[T]()
+}
+
+func newOrderedSet[T comparable]() *orderedSet[T] {
+	return &orderedSet[T]{m: map[T]int{}}
+}
+
+type orderedSet[T comparable] struct {
+	m   map[T]int
+	arr []T
+	mx  sync.RWMutex
+}
+
+func (c *orderedSet[T]) Iterator() IIterator[T] {
+	return newArrayIterator[T](c.ToArray())
+}
+
+func (c *orderedSet[T]) ForEach(f IterFunc[T]) {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	for _, item := range c.arr {
+		f(item)
+	}
+}
+
+func (c *orderedSet[T]) Add(items ...T) bool {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	l := len(c.arr)
+
+	for _, item := range items {
+		if _, ok := c.m[item]; ok {
+			continue
+		}
+		c.m[item] = len(c.arr)
+		c.arr = append(c.arr, item)
+	}
+
+	return len(c.arr) > l
+}
+
+func (c *orderedSet[T]) Index(index int) (ret T, exists bool) {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	if index < 0 || index >= len(c.arr) {
+		return
+	}
+	return c.arr[index], true
+}
+
+func (c *orderedSet[T]) RemoveAt(index int, _ ...bool) bool {
+	item, ok := c.Index(index)
+	return ok && c.Remove(item)
+}
+
+func (c *orderedSet[T]) AddFromIterator(iterator IIterator[T]) bool {
+	ret := false
+	iterator.ForEachRemaining(func(item T) {
+		ret = c.Add(item) || ret
+	})
+	return ret
+}
+
+func (c *orderedSet[T]) Remove(items ...T) bool {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	l := len(c.arr)
+
+	for _, item := range items {
+		i, ok := c.m[item]
+		if !ok {
+			continue
+		}
+		delete(c.m, item)
+		c.arr = append(c.arr[:i], c.arr[i+1:]...)
+		for j := i; j < len(c.arr); j++ {
+			c.m[c.arr[j]] = j
+		}
+	}
+
+	return len(c.arr) < l
+}
+
+func (c *orderedSet[T]) RemoveFromIterator(iterator IIterator[T]) bool {
+	ret := false
+	iterator.ForEachRemaining(func(item T) {
+		ret = c.Remove(item) || ret
+	})
+	return ret
+}
+
+func (c *orderedSet[T]) RemoveIf(condition ConditionalFunc[T], _ ...bool) bool {
+	var toRemove []T
+	c.ForEach(func(item T) {
+		if condition(item) {
+			toRemove = append(toRemove, item)
+		}
+	})
+	if len(toRemove) == 0 {
+		return false
+	}
+
+	return c.Remove(toRemove...)
+}
+
+func (c *orderedSet[T]) Contains(items ...T) bool {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	for _, item := range items {
+		if _, ok := c.m[item]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *orderedSet[T]) ContainsFromIterator(iterator IIterator[T]) bool {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	for val := iterator.Current(); iterator.HasNext(); val = iterator.Next() {
+		if _, ok := c.m[val]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *orderedSet[T]) Len() int {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	return len(c.arr)
+}
+
+func (c *orderedSet[T]) Clear() {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	c.m = map[T]int{}
+	c.arr = nil
+}
+
+func (c *orderedSet[T]) ToArray() []T {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	ret := make([]T, len(c.arr))
+	copy(ret, c.arr)
+	return ret
+}
+
+func (c *orderedSet[T]) IsEmpty() bool {
+	return c.Len() == 0
+}
+
+func (c *orderedSet[T]) Equals(other ICollection[T]) bool {
+	return collectionEquals[T](c, other)
+}
+
+func (c *orderedSet[T]) EqualsUnordered(other ICollection[T]) bool {
+	return collectionEqualsUnordered[T](c, other)
+}
+
+// SetIndex replaces the element at the provided index, as long as the value is not already contained by the set in a
+// different position
+func (c *orderedSet[T]) SetIndex(index int, value T) bool {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if index < 0 || index >= len(c.arr) {
+		return false
+	}
+	if i, ok := c.m[value]; ok {
+		return i == index
+	}
+
+	delete(c.m, c.arr[index])
+	c.m[value] = index
+	c.arr[index] = value
+	return true
+}
+
+// Fill is not supported by sets, since the elements have to be unique
+func (c *orderedSet[T]) Fill(_ T) bool {
+	return false
+}
+
+func (c *orderedSet[T]) EnsureCapacity(n int) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if cap(c.arr) >= n {
+		return
+	}
+	arr := make([]T, len(c.arr), n)
+	copy(arr, c.arr)
+	c.arr = arr
+}
+
+func (c *orderedSet[T]) TrimToSize() {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if cap(c.arr) == len(c.arr) {
+		return
+	}
+	arr := make([]T, len(c.arr))
+	copy(arr, c.arr)
+	c.arr = arr
+}
+
+func (c *orderedSet[T]) Distinct() ISet[T] {
+	ret := newOrderedSet[T]()
+	ret.Add(c.ToArray()...)
+	return ret
+}
+
+func (c *orderedSet[T]) Union(other ICollection[T]) ISet[T] {
+	ret := newOrderedSet[T]()
+	ret.Add(c.ToArray()...)
+	ret.Add(other.ToArray()...)
+	return ret
+}
+
+func (c *orderedSet[T]) Intersect(other ICollection[T]) ISet[T] {
+	ret := newOrderedSet[T]()
+	c.ForEach(func(item T) {
+		if other.Contains(item) {
+			ret.Add(item)
+		}
+	})
+	return ret
+}
+
+func (c *orderedSet[T]) Except(other ICollection[T]) ISet[T] {
+	ret := newOrderedSet[T]()
+	c.ForEach(func(item T) {
+		if !other.Contains(item) {
+			ret.Add(item)
+		}
+	})
+	return ret
+}
+
+func (c *orderedSet[T]) Stream() IStream[T] {
+	return FromCollection[T](c)
+}
@@ -0,0 +1,171 @@
+package streams
+
+import (
+	"sort"
+	"sync"
+)
+
+var (
+	// To ensure *sortedSet implements ISet on build
+	_ ISet[string]        = (*sortedSet[string])(nil)
+	_ ICollection[string] = (*sortedSet[string])(nil)
+)
+
+// NewSortedSet creates a new set which keeps its elements ordered by the provided SortFunc, so iterating over the set
+// or calling ToArray always produces the elements in sorted order. Similar to a TreeSet, the SortFunc also determines
+// the uniqueness of the elements: two elements are considered the same if the SortFunc returns 0 when comparing them.
+func NewSortedSet[T comparable](cmp SortFunc[T]) ISet[T] {
+	return &sortedSet[T]{cmp: cmp}
+}
+
+type sortedSet[T comparable] struct {
+	arr []T
+	cmp SortFunc[T]
+	mx  sync.RWMutex
+}
+
+func (c *sortedSet[T]) Iterator() IIterator[T] {
+	return newArrayIterator[T](c.ToArray())
+}
+
+func (c *sortedSet[T]) ForEach(f IterFunc[T]) {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	for _, item := range c.arr {
+		f(item)
+	}
+}
+
+func (c *sortedSet[T]) Add(items ...T) bool {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	l := len(c.arr)
+
+	for _, item := range items {
+		i, found := c.search(item)
+		if found {
+			continue
+		}
+		var zero T
+		c.arr = append(c.arr, zero)
+		copy(c.arr[i+1:], c.arr[i:])
+		c.arr[i] = item
+	}
+
+	return len(c.arr) > l
+}
+
+func (c *sortedSet[T]) AddFromIterator(iterator IIterator[T]) bool {
+	ret := false
+	iterator.ForEachRemaining(func(item T) {
+		ret = c.Add(item) || ret
+	})
+	return ret
+}
+
+func (c *sortedSet[T]) Remove(items ...T) bool {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	l := len(c.arr)
+
+	for _, item := range items {
+		if i, found := c.search(item); found {
+			c.arr = append(c.arr[:i], c.arr[i+1:]...)
+		}
+	}
+
+	return len(c.arr) < l
+}
+
+func (c *sortedSet[T]) RemoveFromIterator(iterator IIterator[T]) bool {
+	ret := false
+	iterator.ForEachRemaining(func(item T) {
+		ret = c.Remove(item) || ret
+	})
+	return ret
+}
+
+func (c *sortedSet[T]) RemoveIf(condition ConditionalFunc[T], _ ...bool) bool {
+	var toRemove []T
+	c.ForEach(func(item T) {
+		if condition(item) {
+			toRemove = append(toRemove, item)
+		}
+	})
+	if len(toRemove) == 0 {
+		return false
+	}
+
+	return c.Remove(toRemove...)
+}
+
+func (c *sortedSet[T]) Contains(items ...T) bool {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	for _, item := range items {
+		if _, found := c.search(item); !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *sortedSet[T]) ContainsFromIterator(iterator IIterator[T]) bool {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	for val := iterator.Current(); iterator.HasNext(); val = iterator.Next() {
+		if _, found := c.search(val); !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *sortedSet[T]) Len() int {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	return len(c.arr)
+}
+
+func (c *sortedSet[T]) Clear() {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	c.arr = nil
+}
+
+func (c *sortedSet[T]) ToArray() []T {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	ret := make([]T, len(c.arr))
+	copy(ret, c.arr)
+	return ret
+}
+
+func (c *sortedSet[T]) IsEmpty() bool {
+	return c.Len() == 0
+}
+
+func (c *sortedSet[T]) Equals(other ICollection[T]) bool {
+	return collectionEquals[T](c, other)
+}
+
+func (c *sortedSet[T]) EqualsUnordered(other ICollection[T]) bool {
+	return collectionEqualsUnordered[T](c, other)
+}
+
+// search finds the position of the provided item using a binary search. Returns the index where the item is located,
+// or the index where it should be inserted if it is not contained by the set.
+func (c *sortedSet[T]) search(item T) (int, bool) {
+	i := sort.Search(len(c.arr), func(i int) bool {
+		return c.cmp(c.arr[i], item) >= 0
+	})
+	return i, i < len(c.arr) && c.cmp(c.arr[i], item) == 0
+}
@@ -1,12 +1,22 @@
 package streams
 
-import "sort"
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
 
 // ISortable comprises the comparable types that also support   <   >   <=   >=   comparison instead of just  ==
 type ISortable interface {
 	string | int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64 | float32 | float64
 }
 
+// INumber comprises the numeric types that support arithmetic operations and can be converted to float64
+type INumber interface {
+	int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64 | float32 | float64
+}
+
 // ComparableFn creates a new SortFunc[T, T] that knows how to compare default comparable values
 func ComparableFn[T ISortable](desc ...bool) SortFunc[T] {
 	if len(desc) > 0 && desc[0] {
@@ -17,6 +27,38 @@ func ComparableFn[T ISortable](desc ...bool) SortFunc[T] {
 	return defaultComparableFunc[T]
 }
 
+// ICompareTo comprises the comparable types that carry their own ordering through a `CompareTo` function, which returns a
+// negative number if the value is less than the provided one, 0 if equal, or a positive number if greater.
+type ICompareTo[T any] interface {
+	comparable
+	CompareTo(T) int
+}
+
+// CompareBy creates a new SortFunc[T] from a `less` function, which indicates whether `a` should be ordered before `b`
+func CompareBy[T comparable](less func(a, b T) bool) SortFunc[T] {
+	return func(x, y T) int {
+		if less(x, y) {
+			return -1
+		}
+		if less(y, x) {
+			return +1
+		}
+		return 0
+	}
+}
+
+// ComparableOf creates a new SortFunc[T] for types that implement their own ordering with a `CompareTo` function
+func ComparableOf[T ICompareTo[T]](desc ...bool) SortFunc[T] {
+	if len(desc) > 0 && desc[0] {
+		return func(x, y T) int {
+			return y.CompareTo(x)
+		}
+	}
+	return func(x, y T) int {
+		return x.CompareTo(y)
+	}
+}
+
 func Sort[T ISortable](arr []T, desc ...bool) {
 	d := len(desc) > 0 && desc[0]
 
@@ -38,3 +80,41 @@ func defaultComparableFunc[T ISortable](a, b T) int {
 
 	return +1
 }
+
+// hasNaturalOrder indicates whether the values of a type that is only known to be comparable can be compared with
+// `naturalCompare`
+func hasNaturalOrder[T comparable]() bool {
+	var zero T
+	t := reflect.TypeOf(zero)
+	if t == nil {
+		return false
+	}
+
+	switch t.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// naturalCompare compares two values of a type that is only known to be comparable, using the natural order of its
+// underlying kind when it is one of the ISortable kinds. Panics if the values do not have a natural order.
+func naturalCompare[T comparable](a, b T) int {
+	x, y := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	switch x.Kind() {
+	case reflect.String:
+		return strings.Compare(x.String(), y.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return defaultComparableFunc[int64](x.Int(), y.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return defaultComparableFunc[uint64](x.Uint(), y.Uint())
+	case reflect.Float32, reflect.Float64:
+		return defaultComparableFunc[float64](x.Float(), y.Float())
+	}
+
+	panic(fmt.Sprintf("type %T does not have a natural order", a))
+}
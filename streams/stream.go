@@ -1,10 +1,17 @@
 package streams
 
 import (
+	"fmt"
+	"io"
 	"math"
+	"math/rand"
 	"runtime"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 var (
@@ -14,15 +21,38 @@ var (
 
 // Stream is the default stream implementation which allows stream operations on IIterables.
 type Stream[T comparable] struct {
-	iterable ICollection[T]
-	filters  []ConditionalFunc[T]
-	sorts    []sortFunc[T]
-	distinct bool
-	threads  int
+	iterable   ICollection[T]
+	filters    []filterFactory[T]
+	sorts      []sortFunc[T]
+	transforms []transformFunc[T]
+	distinct   bool
+	threads    int
+	stateful   bool
+	sorted     bool
+	stable     bool
+	limited    bool
+	limit      int
+	buffers    []*arrayCollection[T]
+	timeout    time.Duration
+	deadline   int64 // unix nanoseconds, accessed atomically so streams without a timeout do not need to lock
+	err        error
+	onError    []func(error)
+	mx         sync.Mutex
 
 	current ICollection[T]
 }
 
+// filterFunc is the internal representation of a filter, which receives the element and its index in the source
+type filterFunc[T comparable] func(int, T) bool
+
+// filterFactory creates the filtering function to be used every time the stream is processed, so filters that keep a
+// state (such as the ones used by `DistinctBy`) start from a clean state on every run.
+type filterFactory[T comparable] func() filterFunc[T]
+
+// transformFunc transforms the result of the filters and sorts of the stream, for operations which need to be applied
+// to the result as a whole (such as `Cycle`).
+type transformFunc[T comparable] func(ICollection[T]) ICollection[T]
+
 type sortFunc[T comparable] struct {
 	fn   SortFunc[T]
 	desc bool
@@ -39,15 +69,42 @@ func (s *Stream[T]) SetThreads(threads int) IStream[T] {
 }
 
 func (s *Stream[T]) Filter(f ConditionalFunc[T]) IStream[T] {
-	s.filters = append(s.filters, f)
+	s.filters = append(s.filters, func() filterFunc[T] {
+		return func(_ int, x T) bool { return f(x) }
+	})
 	return s
 }
 
-func (s *Stream[T]) Except(f ConditionalFunc[T]) IStream[T] {
-	s.filters = append(s.filters, func(x T) bool { return !f(x) })
+func (s *Stream[T]) FilterIndexed(f func(i int, v T) bool) IStream[T] {
+	s.filters = append(s.filters, func() filterFunc[T] { return f })
 	return s
 }
 
+func (s *Stream[T]) Except(f ConditionalFunc[T]) IStream[T] {
+	return s.Filter(func(x T) bool { return !f(x) })
+}
+
+func (s *Stream[T]) Peek(f IterFunc[T]) IStream[T] {
+	return s.Filter(func(x T) bool {
+		f(x)
+		return true
+	})
+}
+
+func (s *Stream[T]) Trace(label string, f IterFunc[T]) IStream[T] {
+	return s.Peek(func(x T) {
+		writeTrace(label, x)
+		if f != nil {
+			f(x)
+		}
+	})
+}
+
+func (s *Stream[T]) Compact() IStream[T] {
+	var zero T
+	return s.Filter(func(x T) bool { return x != zero })
+}
+
 func (s *Stream[T]) Sort(f SortFunc[T], desc ...bool) IStream[T] {
 	d := false
 
@@ -62,11 +119,438 @@ func (s *Stream[T]) Sort(f SortFunc[T], desc ...bool) IStream[T] {
 	return s
 }
 
+func (s *Stream[T]) SortStable(f SortFunc[T], desc ...bool) IStream[T] {
+	s.stable = true
+	return s.Sort(f, desc...)
+}
+
+func (s *Stream[T]) OrderByAll(cmps ...SortFunc[T]) IStream[T] {
+	for _, f := range cmps {
+		s.Sort(f)
+	}
+	return s
+}
+
+func (s *Stream[T]) AssumeSorted() IStream[T] {
+	s.sorted = true
+	return s
+}
+
 func (s *Stream[T]) Distinct() IStream[T] {
 	s.distinct = true
 	return s
 }
 
+func (s *Stream[T]) Explain() string {
+	source := "unknown length"
+	if s.iterable == nil {
+		source = "none"
+	} else if l := s.iterable.Len(); l >= 0 {
+		source = fmt.Sprintf("%d elements", l)
+	}
+
+	sorts := make([]string, len(s.sorts))
+	for i, so := range s.sorts {
+		sorts[i] = "asc"
+		if so.desc {
+			sorts[i] = "desc"
+		}
+	}
+
+	return fmt.Sprintf("source: %s, filters: %d, sorts: [%s], distinct: %v, threads: %d",
+		source, len(s.filters), strings.Join(sorts, ", "), s.distinct, s.threads)
+}
+
+func (s *Stream[T]) DistinctBounded(maxSeen int) IStream[T] {
+	if maxSeen <= 0 {
+		return s
+	}
+
+	return s.filterStateful(func() ConditionalFunc[T] {
+		seen := make(map[T]struct{}, maxSeen)
+		window := make([]T, maxSeen)
+		next := 0
+
+		return func(x T) bool {
+			if _, ok := seen[x]; ok {
+				return false
+			}
+			if len(seen) == maxSeen {
+				delete(seen, window[next])
+			}
+			seen[x] = struct{}{}
+			window[next] = x
+			next = (next + 1) % maxSeen
+			return true
+		}
+	})
+}
+
+func (s *Stream[T]) String() string {
+	count := "?"
+	if len(s.filters) == 0 && !s.distinct && len(s.transforms) == 0 && s.iterable != nil && s.iterable.Len() >= 0 {
+		count = strconv.Itoa(s.iterable.Len())
+	}
+	return fmt.Sprintf("%s{count:%s, filters:%d, sorted:%v}", s.typeName(), count, len(s.filters), len(s.sorts) > 0)
+}
+
+func (s *Stream[T]) PreviewString(n int) string {
+	var items []string
+	more := false
+
+	s.ForEachUntil(func(x T) bool {
+		if len(items) >= n {
+			more = true
+			return false
+		}
+		items = append(items, fmt.Sprint(x))
+		return true
+	})
+
+	if more {
+		items = append(items, "...")
+	}
+	return fmt.Sprintf("%s[%s]", s.typeName(), strings.Join(items, ", "))
+}
+
+func (s *Stream[T]) JoinToString(sep, prefix, suffix string, fmtFn func(T) string) string {
+	var sb strings.Builder
+	sb.WriteString(prefix)
+
+	first := true
+	s.ForEach(func(x T) {
+		if !first {
+			sb.WriteString(sep)
+		}
+		first = false
+
+		if fmtFn != nil {
+			sb.WriteString(fmtFn(x))
+		} else {
+			sb.WriteString(fmt.Sprint(x))
+		}
+	})
+
+	sb.WriteString(suffix)
+	return sb.String()
+}
+
+func (s *Stream[T]) WithTimeout(d time.Duration) IStream[T] {
+	s.timeout = d
+	return s
+}
+
+func (s *Stream[T]) Err() error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.err
+}
+
+func (s *Stream[T]) OnError(f func(error)) IStream[T] {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.onError = append(s.onError, f)
+	return s
+}
+
+func (s *Stream[T]) UnionWith(other []T) IStream[T] {
+	s.transforms = append(s.transforms, func(col ICollection[T]) ICollection[T] {
+		ret := NewOrderedSet[T]()
+		ret.Add(col.ToArray()...)
+		ret.Add(other...)
+		return ret
+	})
+	return s
+}
+
+func (s *Stream[T]) IntersectWith(other []T) IStream[T] {
+	s.transforms = append(s.transforms, func(col ICollection[T]) ICollection[T] {
+		lookup := NewSet[T]()
+		lookup.Add(other...)
+		ret := NewOrderedSet[T]()
+		col.ForEach(func(x T) {
+			if lookup.Contains(x) {
+				ret.Add(x)
+			}
+		})
+		return ret
+	})
+	return s
+}
+
+func (s *Stream[T]) ExceptWith(other []T) IStream[T] {
+	s.transforms = append(s.transforms, func(col ICollection[T]) ICollection[T] {
+		ret := NewOrderedSet[T]()
+		ret.Add(col.ToArray()...)
+		ret.Remove(other...)
+		return ret
+	})
+	return s
+}
+
+func (s *Stream[T]) Append(items ...T) IStream[T] {
+	s.transforms = append(s.transforms, func(col ICollection[T]) ICollection[T] {
+		if len(items) == 0 {
+			return col
+		}
+
+		if col.Len() >= 0 {
+			arr := col.ToArray()
+			ret := make([]T, 0, len(arr)+len(items))
+			ret = append(ret, arr...)
+			return NewList[T](append(ret, items...))
+		}
+
+		// Lazy sources produce the appended items once the source is exhausted
+		iterator := col.Iterator()
+		started := false
+		i := 0
+
+		return newIterableCollection[T](newFuncIterator[T](func() (ret T, ok bool) {
+			if iterator != nil {
+				if started {
+					ret = iterator.Next()
+				} else {
+					ret = iterator.Current()
+					started = true
+				}
+				if iterator.HasNext() {
+					return ret, true
+				}
+				iterator = nil
+			}
+
+			if i < len(items) {
+				ret, ok = items[i], true
+				i++
+			}
+			return
+		}))
+	})
+	return s
+}
+
+func (s *Stream[T]) Prepend(items ...T) IStream[T] {
+	s.transforms = append(s.transforms, func(col ICollection[T]) ICollection[T] {
+		if len(items) == 0 {
+			return col
+		}
+
+		if col.Len() >= 0 {
+			ret := make([]T, 0, len(items)+col.Len())
+			ret = append(ret, items...)
+			return NewList[T](append(ret, col.ToArray()...))
+		}
+
+		// Lazy sources are not read until the prepended items are produced
+		var iterator IIterator[T]
+		i := 0
+
+		return newIterableCollection[T](newFuncIterator[T](func() (ret T, ok bool) {
+			if i < len(items) {
+				ret, ok = items[i], true
+				i++
+				return
+			}
+
+			if iterator == nil {
+				iterator = col.Iterator()
+				ret = iterator.Current()
+			} else {
+				ret = iterator.Next()
+			}
+			return ret, iterator.HasNext()
+		}))
+	})
+	return s
+}
+
+func (s *Stream[T]) Tap(f func([]T)) IStream[T] {
+	arr := s.ToArray()
+	f(arr)
+	return FromArray[T](arr, s.threads)
+}
+
+func (s *Stream[T]) Cycle(times int) IStream[T] {
+	s.transforms = append(s.transforms, func(col ICollection[T]) ICollection[T] {
+		arr := col.ToArray()
+
+		if times > 0 {
+			ret := make([]T, 0, len(arr)*times)
+			for i := 0; i < times; i++ {
+				ret = append(ret, arr...)
+			}
+			return NewList[T](ret)
+		}
+
+		if len(arr) == 0 {
+			return NewList[T]()
+		}
+
+		i := 0
+		return newIterableCollection[T](newFuncIterator[T](func() (T, bool) {
+			x := arr[i%len(arr)]
+			i++
+			return x, true
+		}))
+	})
+	return s
+}
+
+func (s *Stream[T]) FirstN(n int) IList[T] {
+	ret := NewList[T]()
+	if n <= 0 {
+		return ret
+	}
+
+	s.ForEachUntil(func(x T) bool {
+		_ = ret.Add(x)
+		return ret.Len() < n
+	})
+	return ret
+}
+
+func (s *Stream[T]) LastN(n int) IList[T] {
+	if n <= 0 {
+		return NewList[T]()
+	}
+
+	window := make([]T, 0, n)
+	pos := 0
+	s.ForEach(func(x T) {
+		if len(window) < n {
+			window = append(window, x)
+			return
+		}
+		window[pos] = x
+		pos = (pos + 1) % n
+	})
+	return NewList[T](append(window[pos:], window[:pos]...))
+}
+
+func (s *Stream[T]) Limit(n int) IStream[T] {
+	if n < 0 {
+		n = 0
+	}
+
+	// If the limit is the first transformation, the processing of the stream can stop as soon as enough elements are
+	// found, which allows infinite sources to be limited.
+	if len(s.transforms) == 0 {
+		s.limited = true
+		s.limit = n
+	}
+
+	s.transforms = append(s.transforms, func(col ICollection[T]) ICollection[T] {
+		if col.Len() >= 0 {
+			if col.Len() <= n {
+				return col
+			}
+			return NewList[T](col.ToArray()[:n:n])
+		}
+		return filterPositions[T](col, func(i int) bool { return i < n }, n)
+	})
+	return s
+}
+
+func (s *Stream[T]) EveryNth(n int) IStream[T] {
+	if n <= 1 {
+		return s
+	}
+	s.transforms = append(s.transforms, func(col ICollection[T]) ICollection[T] {
+		return filterPositions[T](col, func(i int) bool { return i%n == 0 })
+	})
+	return s
+}
+
+func (s *Stream[T]) SkipEveryNth(n int) IStream[T] {
+	if n <= 0 {
+		return s
+	}
+	s.transforms = append(s.transforms, func(col ICollection[T]) ICollection[T] {
+		return filterPositions[T](col, func(i int) bool { return i%n != 0 })
+	})
+	return s
+}
+
+func (s *Stream[T]) SkipLast(n int) IStream[T] {
+	s.transforms = append(s.transforms, func(col ICollection[T]) ICollection[T] {
+		if n <= 0 {
+			return col
+		}
+
+		if col.Len() >= 0 {
+			arr := col.ToArray()
+			if n >= len(arr) {
+				return NewList[T]()
+			}
+			return NewList[T](arr[: len(arr)-n : len(arr)-n])
+		}
+
+		// Lazy sources are consumed through a window of the last n elements, every element is released once n elements
+		// after it have been read.
+		iterator := col.Iterator()
+		window := make([]T, 0, n)
+		pos := 0
+		started := false
+
+		return newIterableCollection[T](newFuncIterator[T](func() (ret T, ok bool) {
+			for {
+				var x T
+				if started {
+					x = iterator.Next()
+				} else {
+					x = iterator.Current()
+					started = true
+				}
+
+				if !iterator.HasNext() {
+					return
+				}
+				if len(window) < n {
+					window = append(window, x)
+					continue
+				}
+
+				ret, window[pos] = window[pos], x
+				pos = (pos + 1) % n
+				return ret, true
+			}
+		}))
+	})
+	return s
+}
+
+func (s *Stream[T]) LimitLast(n int) IStream[T] {
+	s.transforms = append(s.transforms, func(col ICollection[T]) ICollection[T] {
+		if n <= 0 {
+			return NewList[T]()
+		}
+
+		if col.Len() >= 0 {
+			arr := col.ToArray()
+			if n >= len(arr) {
+				return col
+			}
+			return NewList[T](arr[len(arr)-n:])
+		}
+
+		// Lazy sources are consumed entirely keeping only a window of the last n elements read.
+		window := make([]T, 0, n)
+		pos := 0
+		iterator := col.Iterator()
+		for x := iterator.Current(); iterator.HasNext(); x = iterator.Next() {
+			if len(window) < n {
+				window = append(window, x)
+				continue
+			}
+			window[pos] = x
+			pos = (pos + 1) % n
+		}
+		return NewList[T](append(window[pos:], window[:pos]...))
+	})
+	return s
+}
+
 func (s *Stream[T]) First(defaultValue ...T) T {
 	return s.At(0, defaultValue...)
 }
@@ -91,8 +575,23 @@ func (s *Stream[T]) At(index int, defaultValue ...T) (ret T) {
 	return
 }
 
-func (s *Stream[T]) AtReverse(pos int, defaultValue ...T) (ret T) {
+func (s *Stream[T]) TryAt(index int) (ret T, err error) {
 	iterable := s.process()
+	if index >= 0 && iterable != nil {
+		iterator := iterable.Iterator()
+		iterator.Skip(index)
+		if iterator.HasNext() {
+			return iterator.Current(), nil
+		}
+	}
+	return ret, fmt.Errorf("%w: %d", ErrIndexOutOfRange, index)
+}
+
+func (s *Stream[T]) AtReverse(pos int, defaultValue ...T) (ret T) {
+	iterable := materialize(s.process())
+	if iterable == nil {
+		return
+	}
 	iterator := iterable.Iterator()
 
 	i := iterable.Len() - 1 - pos
@@ -111,6 +610,9 @@ func (s *Stream[T]) AtReverse(pos int, defaultValue ...T) (ret T) {
 }
 
 func (s *Stream[T]) Count() int {
+	s.begin()
+	defer s.end()
+
 	iterable := s.process()
 
 	if iterable.Len() >= 0 {
@@ -120,28 +622,111 @@ func (s *Stream[T]) Count() int {
 	iterator := iterable.Iterator()
 	size := 0
 
-	for ; iterator.HasNext(); iterator.Next() {
+	for ; iterator.HasNext() && !s.expired(); iterator.Next() {
 		size++
 	}
 
 	return size
 }
 
+func (s *Stream[T]) CountAtLeast(n int) bool {
+	if n <= 0 {
+		return true
+	}
+
+	count := 0
+	s.walk(func(T) bool {
+		count++
+		return count < n
+	})
+	return count >= n
+}
+
+func (s *Stream[T]) CountMatch(f ConditionalFunc[T]) int {
+	count := 0
+	s.ForEach(func(x T) {
+		if f(x) {
+			count++
+		}
+	})
+	return count
+}
+
+func (s *Stream[T]) CountDistinct() int {
+	seen := map[T]struct{}{}
+	s.ForEach(func(x T) {
+		seen[x] = struct{}{}
+	})
+	return len(seen)
+}
+
+func (s *Stream[T]) ReservoirSample(k int, r ...*rand.Rand) IList[T] {
+	intn := rand.Intn
+	if len(r) > 0 && r[0] != nil {
+		intn = r[0].Intn
+	}
+
+	sample := make([]T, 0, k)
+	i := 0
+	s.ForEach(func(x T) {
+		if i < k {
+			sample = append(sample, x)
+		} else if j := intn(i + 1); j < k {
+			sample[j] = x
+		}
+		i++
+	})
+	return NewList[T](sample)
+}
+
 func (s *Stream[T]) IsEmpty() bool {
 	return s.Count() == 0
 }
 
 func (s *Stream[T]) Contains(value T) bool {
+	if s.isPresorted() && len(s.transforms) == 0 {
+		return s.binarySearch(value)
+	}
 	return s.AnyMatch(func(val T) bool {
 		return value == val
 	})
 }
 
+func (s *Stream[T]) EqualTo(other IStream[T]) bool {
+	a, b := s.Iterator(), other.Iterator()
+
+	for x, y := a.Current(), b.Current(); a.HasNext() && b.HasNext(); x, y = a.Next(), b.Next() {
+		if x != y {
+			return false
+		}
+	}
+	return !a.HasNext() && !b.HasNext()
+}
+
+func (s *Stream[T]) EqualAsSet(other IStream[T]) bool {
+	a, b := NewSet[T](), NewSet[T]()
+	a.Add(s.ToArray()...)
+	b.Add(other.ToArray()...)
+	return a.Len() == b.Len() && a.ContainsFromIterator(b.Iterator())
+}
+
 func (s *Stream[T]) AnyMatch(f ConditionalFunc[T]) bool {
 	iterable := s.process()
 	return anyMatch[T](iterable, 0, iterable.Len(), f, false)
 }
 
+func (s *Stream[T]) AnyMatchParallel(f ConditionalFunc[T], threads int) bool {
+	var found int32
+	parallelUntil[T](s.ToArray(), threads, func(x T) bool {
+		if f(x) {
+			atomic.StoreInt32(&found, 1)
+			return false
+		}
+		return true
+	})
+	return atomic.LoadInt32(&found) == 1
+}
+
 func (s *Stream[T]) AllMatch(f ConditionalFunc[T]) bool {
 	iterable := s.process()
 	return !anyMatch[T](iterable, 0, iterable.Len(), f, true)
@@ -184,16 +769,52 @@ func (s *Stream[T]) IfNoneMatch(f ConditionalFunc[T]) IThen[T] {
 }
 
 func (s *Stream[T]) ForEach(f IterFunc[T]) {
-	iterable := s.process()
-	iterator := iterable.Iterator()
+	s.ForEachUntil(func(x T) bool {
+		f(x)
+		return true
+	})
+}
+
+func (s *Stream[T]) ForEachUntil(f func(T) bool) {
+	s.begin()
+	defer s.end()
+
+	s.walk(f)
+}
+
+func (s *Stream[T]) ForEachErr(f func(T) error) (err error) {
+	s.ForEachUntil(func(x T) bool {
+		err = f(x)
+		return err == nil
+	})
+	return
+}
 
-	iterator.ForEachRemaining(f)
+func (s *Stream[T]) WriteTo(w io.Writer, fmtFn func(T) string) (written int64, err error) {
+	if fmtFn == nil {
+		fmtFn = func(x T) string { return fmt.Sprint(x) }
+	}
+
+	s.ForEachUntil(func(x T) bool {
+		var n int
+		n, err = io.WriteString(w, fmtFn(x)+"\n")
+		written += int64(n)
+		return err == nil
+	})
+	return
 }
 
 func (s *Stream[T]) ParallelForEach(f IterFunc[T], threads int, skipWait ...bool) {
+	done := s.ParallelForEachAsync(f, threads)
+	if len(skipWait) == 0 || !skipWait[0] {
+		<-done
+	}
+}
+
+func (s *Stream[T]) ParallelForEachAsync(f IterFunc[T], threads int) <-chan struct{} {
 	var wg sync.WaitGroup
 	cores := getCores(threads)
-	iterable := s.process()
+	iterable := materialize(s.process())
 
 	if iterable.Len() < cores {
 		cores = iterable.Len()
@@ -219,9 +840,55 @@ func (s *Stream[T]) ParallelForEach(f IterFunc[T], threads int, skipWait ...bool
 		go worker(i*sliceSize, (i+1)*sliceSize)
 	}
 
-	if len(skipWait) == 0 || !skipWait[0] {
+	done := make(chan struct{})
+	go func() {
 		wg.Wait()
+		close(done)
+	}()
+	return done
+}
+
+func (s *Stream[T]) ParallelBatch(size, threads int, f func([]T)) {
+	var wg sync.WaitGroup
+	batches := make(chan []T)
+	cores := getCores(threads)
+
+	wg.Add(cores)
+	for i := 0; i < cores; i++ {
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				f(batch)
+			}
+		}()
 	}
+
+	var batch []T
+	s.ForEach(func(x T) {
+		batch = append(batch, x)
+		if size > 0 && len(batch) >= size {
+			batches <- batch
+			batch = nil
+		}
+	})
+	if len(batch) > 0 {
+		batches <- batch
+	}
+
+	close(batches)
+	wg.Wait()
+}
+
+func (s *Stream[T]) ParallelForEachErr(f func(T) error, threads int) (err error) {
+	var once sync.Once
+	parallelUntil[T](s.ToArray(), threads, func(x T) bool {
+		if e := f(x); e != nil {
+			once.Do(func() { err = e })
+			return false
+		}
+		return true
+	})
+	return
 }
 
 func (s *Stream[T]) ToArray() []T {
@@ -240,6 +907,14 @@ func (s *Stream[T]) ToIterable() IIterable[T] {
 	return s.process()
 }
 
+func (s *Stream[T]) Iterator() IIterator[T] {
+	iterable := s.process()
+	if iterable == nil {
+		return newArrayIterator[T]()
+	}
+	return iterable.Iterator()
+}
+
 func (s *Stream[T]) ToList() IList[T] {
 	col := s.ToCollection()
 	switch ret := col.(type) {
@@ -250,11 +925,75 @@ func (s *Stream[T]) ToList() IList[T] {
 }
 
 func (s *Stream[T]) ToDistinct() ISet[T] {
-	return s.Distinct().ToCollection().(ISet[T])
+	// The stream is not marked as distinct, so it can still be processed with duplicates afterwards. The results are
+	// usually a list (which also satisfies the ISet contract without behaving as a set), but transforms such as
+	// `UnionWith` produce a new set, which is returned as is unless it is the source of the stream.
+	col := s.ToCollection()
+	switch ret := col.(type) {
+	case *set[T], *orderedSet[T]:
+		if col != s.iterable {
+			return ret.(ISet[T])
+		}
+	}
+	ret := NewSet[T]()
+	if col != nil {
+		ret.Add(col.ToArray()...)
+	}
+	return ret
+}
+
+func (s *Stream[T]) ToSortedArray(cmp SortFunc[T], desc ...bool) []T {
+	// The array is copied since it may belong to the source of the stream, which should not be modified
+	arr := s.ToArray()
+	ret := make([]T, len(arr))
+	copy(ret, arr)
+
+	d := len(desc) > 0 && desc[0]
+	sort.SliceStable(ret, func(i, j int) bool {
+		if d {
+			return cmp(ret[i], ret[j]) > 0
+		}
+		return cmp(ret[i], ret[j]) < 0
+	})
+	return ret
+}
+
+func (s *Stream[T]) ToSortedList(cmp SortFunc[T], desc ...bool) IList[T] {
+	return NewList[T](s.ToSortedArray(cmp, desc...))
+}
+
+func (s *Stream[T]) DistinctByHash(hash func(T) uint64, eq func(a, b T) bool) IList[T] {
+	ret := NewList[T]()
+	buckets := map[uint64][]T{}
+
+	s.ForEach(func(x T) {
+		h := hash(x)
+		for _, y := range buckets[h] {
+			if eq(x, y) {
+				return
+			}
+		}
+		buckets[h] = append(buckets[h], x)
+		_ = ret.Add(x)
+	})
+	return ret
+}
+
+func (s *Stream[T]) ToOrderedSet() IList[T] {
+	ret := newOrderedSet[T]()
+	s.ForEach(func(x T) {
+		ret.Add(x)
+	})
+	return ret
 }
 
 func (s *Stream[T]) process() ICollection[T] {
-	if s.threads != 1 {
+	// The buffers of previous results are no longer tracked, since they may have been returned by a terminal operation
+	s.mx.Lock()
+	s.buffers = nil
+	s.mx.Unlock()
+
+	if s.threads != 1 && !s.stateful {
 		return s.parallelProcess(s.threads)
 	}
 
@@ -264,14 +1003,22 @@ func (s *Stream[T]) process() ICollection[T] {
 	}
 	iterable = s.filter(iterable)
 	iterable = s.sort(iterable)
+	iterable = s.transform(iterable)
 	s.current = iterable
 	return iterable
 }
 
 func (s *Stream[T]) parallelProcess(threads int) ICollection[T] {
 	iterable := s.iterable
-	iterable = s.parallelProcessHandler(iterable, threads)
+	if iterable == nil {
+		return nil
+	}
+	if len(s.filters) > 0 || s.distinct {
+		iterable = s.parallelProcessHandler(iterable, threads)
+	}
 	iterable = s.sort(iterable)
+	iterable = s.transform(iterable)
+	s.current = iterable
 	return iterable
 }
 
@@ -284,31 +1031,38 @@ func (s *Stream[T]) iterHandler(iterable ICollection[T], start, end int) ICollec
 		return s.iterable
 	}
 
-	var ret ICollection[T]
+	filters := s.newFilters()
 	iterator := iterable.Iterator().Skip(start)
 	i := start
 
-	if s.distinct {
-		ret = NewSet[T]()
-	} else {
-		ret = NewList[T]()
+	// If a limit is staged, no more than the limit elements are kept, which caps the capacity hint for known sizes
+	size := end - start
+	limit, limited := s.earlyLimit()
+	if limited && end >= 0 && limit < size {
+		size = limit
 	}
 
-	for x := iterator.Current(); iterator.HasNext() && i < end; x = iterator.Next() {
-		i++
-		match := true
+	ret := s.newBuffer()
+	if limited && end >= 0 {
+		ret.EnsureCapacity(size)
+	}
 
-		for _, f := range s.filters {
-			match = match && f(x)
+	// Distinct results are also collected into a list, keeping the order in which the elements were found, so they can
+	// be returned by `ToList` or `ToArray` without copying them.
+	var seen map[T]struct{}
+	if s.distinct {
+		// The size of the segment is used as a capacity hint when known, so the map does not rehash as it grows
+		seen = newSeenCap[T](size)
+	}
 
-			if !match {
+	for x := iterator.Current(); iterator.HasNext() && (end < 0 || i < end) && !s.expired(); x = iterator.Next() {
+		if matchAll(filters, i, x) && markSeen(seen, x) {
+			_ = ret.Add(x)
+			if limited && ret.Len() >= limit {
 				break
 			}
 		}
-
-		if match {
-			_ = ret.Add(x)
-		}
+		i++
 	}
 
 	return ret
@@ -319,7 +1073,7 @@ func (s *Stream[T]) parallelProcessHandler(iterable ICollection[T], threads int)
 		result <- s.iterHandler(iterable, start, end)
 	}
 
-	ret := NewList[T]()
+	iterable = materialize(iterable)
 	cores := getCores(threads)
 
 	if iterable.Len() < cores {
@@ -329,13 +1083,26 @@ func (s *Stream[T]) parallelProcessHandler(iterable ICollection[T], threads int)
 	sliceSize := int(math.Ceil(float64(iterable.Len()) / float64(cores)))
 	c := make(chan ICollection[T], cores)
 
+	// Each worker dedups its own segment only, so the results are merged verifying that the elements are unique across
+	// segments when distinct is enabled.
+	ret := NewList[T]()
+	var seen map[T]struct{}
+	if s.distinct {
+		seen = newSeenCap[T](iterable.Len())
+	}
+
 	for i := 0; i < cores; i++ {
 		go worker(c, i*sliceSize, (i+1)*sliceSize)
 	}
 
 	for i := 0; i < cores; i++ {
 		func(iter ICollection[T]) {
-			iter.ForEach(func(item T) { ret.Add(item) })
+			iter.ForEach(func(item T) {
+				if markSeen(seen, item) {
+					ret.Add(item)
+				}
+			})
+			s.releaseBuffer(iter)
 		}(<-c)
 	}
 
@@ -343,20 +1110,185 @@ func (s *Stream[T]) parallelProcessHandler(iterable ICollection[T], threads int)
 }
 
 func (s *Stream[T]) sort(iterable ICollection[T]) ICollection[T] {
-	if len(s.sorts) == 0 {
+	if len(s.sorts) == 0 || s.isPresorted() {
 		return iterable
 	}
 
+	array := iterable.ToArray()
+
+	// Intermediate results can be sorted in place, but if no filters were applied the array may belong to the source of
+	// the stream, which should not be modified.
+	if iterable == s.iterable {
+		buffer := s.newBuffer()
+		buffer.Add(array...)
+		array = buffer.ToArray()
+	}
+
 	so := sorter[T]{
-		array: iterable.ToArray(),
+		array: array,
 		sorts: s.sorts,
 	}
 
-	sort.Slice(so.array, so.makeLessFunc())
+	if s.stable {
+		sort.SliceStable(so.array, so.makeLessFunc())
+	} else {
+		sort.Slice(so.array, so.makeLessFunc())
+	}
 	v := NewList[T](so.array)
 	return v
 }
 
+func (s *Stream[T]) typeName() string {
+	var t T
+	return fmt.Sprintf("Stream[%T]", t)
+}
+
+// begin prepares the stream for a terminal operation, setting the deadline of the operation if a timeout was provided
+func (s *Stream[T]) begin() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.err = nil
+	if s.timeout > 0 {
+		atomic.StoreInt64(&s.deadline, time.Now().Add(s.timeout).UnixNano())
+	}
+}
+
+// end clears the deadline set for a terminal operation
+func (s *Stream[T]) end() {
+	atomic.StoreInt64(&s.deadline, 0)
+}
+
+// expired indicates whether the deadline of the current terminal operation was reached, in which case ErrTimeout is
+// reported as the error of the stream. Verified for every element, so the lock is only taken once the deadline is
+// reached.
+func (s *Stream[T]) expired() bool {
+	deadline := atomic.LoadInt64(&s.deadline)
+	if deadline == 0 || time.Now().UnixNano() < deadline {
+		return false
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.err = ErrTimeout
+	return true
+}
+
+// reportError notifies the error produced by the source of the stream to the handlers registered with `OnError`
+func (s *Stream[T]) reportError(err error) {
+	s.mx.Lock()
+	handlers := s.onError
+	s.mx.Unlock()
+
+	for _, f := range handlers {
+		f(err)
+	}
+}
+
+// walk lazily iterates over the source of the stream applying the staged filters and distinct, invoking the provided
+// function for every element of the result until it returns false, so only the elements needed are read from the source.
+// If the stream has to be processed as a whole (sorts are staged or parallel processing is enabled), the stream is
+// processed first and the function is invoked for the elements of the result.
+func (s *Stream[T]) walk(f func(T) bool) {
+	lazy := (len(s.sorts) == 0 || s.isPresorted()) && len(s.transforms) == 0 && (s.threads == 1 || s.stateful)
+
+	if !lazy {
+		iterable := s.process()
+		if iterable == nil {
+			return
+		}
+		// The intermediate results are not needed once iterated, so their buffers can be reused
+		defer s.releaseBuffers()
+
+		iterator := iterable.Iterator()
+		for x := iterator.Current(); iterator.HasNext() && !s.expired(); x = iterator.Next() {
+			if !f(x) {
+				return
+			}
+		}
+		return
+	}
+
+	if s.iterable == nil {
+		return
+	}
+
+	filters := s.newFilters()
+	var seen map[T]struct{}
+	if s.distinct {
+		seen = newSeenCap[T](0)
+	}
+
+	iterator := s.iterable.Iterator()
+	i := -1
+	for x := iterator.Current(); iterator.HasNext() && !s.expired(); x = iterator.Next() {
+		i++
+		if !matchAll(filters, i, x) || !markSeen(seen, x) {
+			continue
+		}
+		if !f(x) {
+			return
+		}
+	}
+}
+
+// newFilters creates the filtering functions for a new run of the stream
+func (s *Stream[T]) newFilters() []filterFunc[T] {
+	filters := make([]filterFunc[T], len(s.filters))
+	for i, factory := range s.filters {
+		filters[i] = factory()
+	}
+	return filters
+}
+
+// filterStateful appends a filter whose function is created by the provided factory every time the stream is processed.
+// Since the state of the filter cannot be shared across parallel segments, streams with stateful filters are always
+// processed sequentially.
+func (s *Stream[T]) filterStateful(factory func() ConditionalFunc[T]) IStream[T] {
+	s.filters = append(s.filters, func() filterFunc[T] {
+		f := factory()
+		return func(_ int, x T) bool { return f(x) }
+	})
+	s.stateful = true
+	return s
+}
+
+// isPresorted indicates whether the result of processing the stream is already in the order of the staged sorts, which
+// is the case when the source was assumed to be sorted and the processing does not alter the order of the source. Filters
+// and distinct keep the elements in the order they were found when processed sequentially, but parallel segments are
+// merged in the order they finish.
+func (s *Stream[T]) isPresorted() bool {
+	return s.sorted && len(s.sorts) > 0 && (s.threads == 1 || s.stateful)
+}
+
+// binarySearch finds the provided value in the processed result of a presorted stream
+func (s *Stream[T]) binarySearch(value T) bool {
+	iterable := materialize(s.process())
+	if iterable == nil {
+		return false
+	}
+
+	arr := iterable.ToArray()
+	i := sort.Search(len(arr), func(i int) bool {
+		return compareSorts(s.sorts, arr[i], value) >= 0
+	})
+
+	// Different values may be equivalent for the sort functions, so all equivalent elements are checked
+	for ; i < len(arr) && compareSorts(s.sorts, arr[i], value) == 0; i++ {
+		if arr[i] == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Stream[T]) transform(iterable ICollection[T]) ICollection[T] {
+	for _, t := range s.transforms {
+		iterable = t(iterable)
+	}
+	return iterable
+}
+
 func (s *Stream[T]) updateCores(threads ...int) int {
 	if len(threads) > 0 {
 		s.threads = getCores(threads...)
@@ -366,26 +1298,64 @@ func (s *Stream[T]) updateCores(threads ...int) int {
 
 func (s *sorter[T]) makeLessFunc() func(int, int) bool {
 	return func(x, y int) bool {
-		val := 0
-
-		for i := 0; val == 0 && i < len(s.sorts); i++ {
-			sorter := s.sorts[i]
-			val = sorter.fn(s.array[x], s.array[y])
+		return compareSorts(s.sorts, s.array[x], s.array[y]) < 0
+	}
+}
 
-			if sorter.desc {
-				val = val * -1
-			}
+// matchAll indicates whether the provided element meets the conditions of all the filters
+func matchAll[T comparable](filters []filterFunc[T], i int, x T) bool {
+	for _, f := range filters {
+		if !f(i, x) {
+			return false
 		}
+	}
+	return true
+}
 
-		return val < 0
+// newSeenCap creates the map used to track the elements already found by distinct streams, using the provided capacity
+// as a hint when known
+func newSeenCap[T comparable](capacity int) map[T]struct{} {
+	if capacity < 0 {
+		capacity = 0
 	}
+	return make(map[T]struct{}, capacity)
+}
+
+// markSeen indicates whether the element is found for the first time, tracking it as found. Every element is considered
+// new if the map is nil, which is the case when distinct is not enabled.
+func markSeen[T comparable](seen map[T]struct{}, x T) bool {
+	if seen == nil {
+		return true
+	}
+	if _, ok := seen[x]; ok {
+		return false
+	}
+	seen[x] = struct{}{}
+	return true
+}
+
+// compareSorts compares two elements using the provided sort functions, where every sort function is only used if the
+// previous ones consider the elements equal.
+func compareSorts[T comparable](sorts []sortFunc[T], x, y T) int {
+	val := 0
+
+	for i := 0; val == 0 && i < len(sorts); i++ {
+		sorter := sorts[i]
+		val = sorter.fn(x, y)
+
+		if sorter.desc {
+			val = val * -1
+		}
+	}
+
+	return val
 }
 
 func anyMatch[T comparable](iterable IIterable[T], start, end int, f ConditionalFunc[T], negate bool) bool {
 	iterator := iterable.Iterator().Skip(start)
 	i := start
 
-	for x := iterator.Current(); iterator.HasNext() && i < end; x = iterator.Next() {
+	for x := iterator.Current(); iterator.HasNext() && (end < 0 || i < end); x = iterator.Next() {
 		match := true
 
 		if negate {
@@ -402,9 +1372,137 @@ func anyMatch[T comparable](iterable IIterable[T], start, end int, f Conditional
 	return false
 }
 
+// earlyLimit returns the limit of elements after which the filtering of the stream can stop, which is only possible if
+// the limit is the first transformation and the elements do not need to be sorted afterwards.
+func (s *Stream[T]) earlyLimit() (int, bool) {
+	return s.limit, s.limited && (len(s.sorts) == 0 || s.isPresorted())
+}
+
+// newBuffer creates a new list for intermediate results, backed by a buffer from the pool if buffer pooling is enabled.
+// See `SetBufferPooling`.
+func (s *Stream[T]) newBuffer() *arrayCollection[T] {
+	buf, pooled := getBuffer[T]()
+	ret := newArrayCollection[T](buf)
+	if pooled {
+		s.mx.Lock()
+		s.buffers = append(s.buffers, ret)
+		s.mx.Unlock()
+	}
+	return ret
+}
+
+// releaseBuffer returns the buffer of an intermediate result to the pool, if it was obtained from it
+func (s *Stream[T]) releaseBuffer(col ICollection[T]) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	for i, buffer := range s.buffers {
+		if buffer == col {
+			s.buffers = append(s.buffers[:i], s.buffers[i+1:]...)
+			putBuffer(buffer.arr)
+			buffer.arr = nil
+			return
+		}
+	}
+}
+
+// releaseBuffers returns the buffers of all the intermediate results of the last processing of the stream to the pool
+func (s *Stream[T]) releaseBuffers() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	for _, buffer := range s.buffers {
+		putBuffer(buffer.arr)
+		buffer.arr = nil
+	}
+	s.buffers = nil
+	s.current = nil
+}
+
+// parallelUntil invokes the provided function concurrently for the elements of the array by multiple go routines, which
+// take the next element to process until all the elements are processed or the function returns false for any of them,
+// which stops the others from taking more elements.
+func parallelUntil[T any](arr []T, threads int, f func(T) bool) {
+	cores := getCores(threads)
+	if len(arr) < cores {
+		cores = len(arr)
+	}
+
+	var wg sync.WaitGroup
+	var stop int32
+	var next int64 = -1
+
+	wg.Add(cores)
+	for i := 0; i < cores; i++ {
+		go func() {
+			defer wg.Done()
+			for atomic.LoadInt32(&stop) == 0 {
+				j := atomic.AddInt64(&next, 1)
+				if j >= int64(len(arr)) {
+					return
+				}
+				if !f(arr[j]) {
+					atomic.StoreInt32(&stop, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// filterPositions keeps the elements of the collection whose position satisfies the provided condition. Collections of
+// unknown length are filtered lazily. If provided, `max` indicates the amount of positions after which no more elements
+// are kept, so the collection is not read any further.
+func filterPositions[T comparable](col ICollection[T], keep func(int) bool, max ...int) ICollection[T] {
+	iterator := col.Iterator()
+	if col.Len() >= 0 {
+		ret := NewList[T]()
+		i := 0
+		for x := iterator.Current(); iterator.HasNext(); x = iterator.Next() {
+			if keep(i) {
+				_ = ret.Add(x)
+			}
+			i++
+		}
+		return ret
+	}
+
+	i := -1
+	started := false
+	return newIterableCollection[T](newFuncIterator[T](func() (ret T, ok bool) {
+		for {
+			if len(max) > 0 && i+1 >= max[0] {
+				return
+			}
+			if started {
+				ret = iterator.Next()
+			} else {
+				ret = iterator.Current()
+				started = true
+			}
+			if !iterator.HasNext() {
+				return
+			}
+			i++
+			if keep(i) {
+				return ret, true
+			}
+		}
+	}))
+}
+
+// materialize ensures the provided collection has a known size. Lazy collections which report an unknown length are
+// read completely into a new list.
+func materialize[T comparable](col ICollection[T]) ICollection[T] {
+	if col == nil || col.Len() >= 0 {
+		return col
+	}
+	return NewList[T](col.ToArray())
+}
+
 func getCores(threads ...int) int {
 	if len(threads) == 0 {
-		return 1
+		return DefaultThreads()
 	}
 
 	maxCores := runtime.NumCPU()
@@ -0,0 +1,68 @@
+package streams
+
+var (
+	// To ensure *groupedStream implements IGroupedStream on build
+	_ IGroupedStream[string, string] = (*groupedStream[string, string])(nil)
+)
+
+// GroupBy processes the stream grouping its elements by the key produced by `keyFn`, returning a grouped stream which
+// allows further operations to be applied per group.
+//
+//	Eg:   countPerRegion := streams.GroupBy[*Sale, string](stream, func(s *Sale) string { return s.Region }).Count()
+//
+// NOTE: Golang generics do not allow passing generics to functions that are part of a structure or interface, so this
+// function could not be a part of IStream.
+func GroupBy[T comparable, K comparable](s IStream[T], keyFn func(T) K) IGroupedStream[K, T] {
+	groups := NewMap[K, IList[T]]()
+	s.ForEach(func(x T) {
+		_ = groups.ComputeIfAbsent(keyFn(x), func(K) IList[T] { return NewList[T]() }).Add(x)
+	})
+	return &groupedStream[K, T]{groups: groups}
+}
+
+type groupedStream[K comparable, T comparable] struct {
+	groups IMap[K, IList[T]]
+}
+
+func (g *groupedStream[K, T]) Keys() []K {
+	return g.groups.Keys()
+}
+
+func (g *groupedStream[K, T]) Count() IMap[K, int] {
+	ret := NewMap[K, int]()
+	g.each(func(k K, group IList[T]) {
+		ret.Set(k, group.Len())
+	})
+	return ret
+}
+
+func (g *groupedStream[K, T]) Reduce(f func(acc T, x T) T) IMap[K, T] {
+	ret := NewMap[K, T]()
+	g.each(func(k K, group IList[T]) {
+		arr := group.ToArray()
+		acc := arr[0]
+		for _, x := range arr[1:] {
+			acc = f(acc, x)
+		}
+		ret.Set(k, acc)
+	})
+	return ret
+}
+
+func (g *groupedStream[K, T]) ForEach(f func(K, IStream[T])) {
+	g.each(func(k K, group IList[T]) {
+		f(k, group.Stream())
+	})
+}
+
+func (g *groupedStream[K, T]) ToMap() IMap[K, IList[T]] {
+	return g.groups
+}
+
+// each iterates over the groups in the order their keys were first found
+func (g *groupedStream[K, T]) each(f func(K, IList[T])) {
+	for _, k := range g.groups.Keys() {
+		group, _ := g.groups.Get(k)
+		f(k, group)
+	}
+}
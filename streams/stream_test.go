@@ -3,11 +3,17 @@ package streams
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"math"
 	"math/rand"
+	"os"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -56,6 +62,32 @@ func TestStream_AnyMatch(t *testing.T) {
 	assert.True(t, match)
 }
 
+func TestStream_AnyMatchParallel(t *testing.T) {
+	arr := make([]int, 10000)
+	for i := range arr {
+		arr[i] = i
+	}
+
+	var evaluated int64
+	slow := func(target int) ConditionalFunc[int] {
+		return func(x int) bool {
+			atomic.AddInt64(&evaluated, 1)
+			time.Sleep(10 * time.Microsecond)
+			return x == target
+		}
+	}
+
+	assert.True(t, From[int](arr).AnyMatchParallel(slow(100), 4))
+	// The remaining elements are not evaluated once a match is found
+	assert.Less(t, atomic.LoadInt64(&evaluated), int64(len(arr)))
+
+	atomic.StoreInt64(&evaluated, 0)
+	assert.False(t, From[int](arr[:200]).AnyMatchParallel(slow(-1), 4))
+	assert.Equal(t, int64(200), atomic.LoadInt64(&evaluated))
+
+	assert.False(t, From[int]([]int{}).AnyMatchParallel(slow(0), 4))
+}
+
 func TestStream_NoneMatch(t *testing.T) {
 	var falseFunc = func(x string) bool {
 		return false
@@ -161,6 +193,96 @@ func TestStream_ParallelForEach(t *testing.T) {
 	}
 }
 
+func TestStream_ParallelForEachErr(t *testing.T) {
+	arr := make([]int, 10000)
+	for i := range arr {
+		arr[i] = i
+	}
+
+	var processed int64
+	err := From[int](arr).ParallelForEachErr(func(x int) error {
+		atomic.AddInt64(&processed, 1)
+		if x == 100 {
+			return fmt.Errorf("failed to process %d", x)
+		}
+		time.Sleep(10 * time.Microsecond)
+		return nil
+	}, 4)
+
+	assert.EqualError(t, err, "failed to process 100")
+	// The remaining elements are not processed after the failure
+	assert.Less(t, atomic.LoadInt64(&processed), int64(len(arr)))
+
+	atomic.StoreInt64(&processed, 0)
+	err = From[int](arr).ParallelForEachErr(func(x int) error {
+		atomic.AddInt64(&processed, 1)
+		return nil
+	}, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(arr)), atomic.LoadInt64(&processed))
+}
+
+func TestStream_ParallelBatch(t *testing.T) {
+	const sampleSize = 1003
+
+	arr := make([]int, sampleSize)
+	for i := range arr {
+		arr[i] = i
+	}
+
+	var mx sync.Mutex
+	processed := make([]int, sampleSize)
+	batches := 0
+
+	From[int](arr).ParallelBatch(10, 4, func(batch []int) {
+		assert.LessOrEqual(t, len(batch), 10)
+
+		mx.Lock()
+		defer mx.Unlock()
+		batches++
+		for _, x := range batch {
+			processed[x]++
+		}
+	})
+
+	assert.Equal(t, 101, batches)
+	for i, count := range processed {
+		assert.Equal(t, 1, count, "element %d", i)
+	}
+
+	var single [][]int
+	From[int](arr).ParallelBatch(0, 2, func(batch []int) {
+		single = append(single, batch)
+	})
+	assert.Len(t, single, 1)
+	assert.Equal(t, arr, single[0])
+}
+
+func TestStream_ParallelForEachAsync(t *testing.T) {
+	const sampleSize = 10000
+
+	arr := make([]int, sampleSize)
+	for i := range arr {
+		arr[i] = i
+	}
+
+	var processed int64
+	done := From[int](arr).ParallelForEachAsync(func(x int) {
+		time.Sleep(time.Microsecond)
+		atomic.AddInt64(&processed, 1)
+	}, 4)
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the processing did not complete")
+	}
+	assert.Equal(t, int64(sampleSize), atomic.LoadInt64(&processed))
+
+	// Empty streams are done immediately
+	<-From[int]([]int{}).ParallelForEachAsync(func(int) {}, 4)
+}
+
 // This test may fail when running with coverage with IntelliJ due to the coverage capture that may affect
 // the performance of go channels. Running normally on a 2 CPU host, demonstrates an efficiency of around 200% vs non-parallel.
 func TestStream_ParallelFiltering(t *testing.T) {
@@ -285,6 +407,148 @@ func TestStream_Distinct(t *testing.T) {
 	assert.Equal(t, len(result), len(testArray))
 }
 
+func TestStream_Peek(t *testing.T) {
+	var peeked []string
+	result := From[string](testArray).
+		Filter(func(x string) bool { return strings.HasPrefix(x, "p") }).
+		Peek(func(x string) { peeked = append(peeked, x) }).
+		Filter(func(x string) bool { return len(x) == 4 }).
+		ToArray()
+
+	assert.Equal(t, []string{"peach", "pear", "plum", "pineapple"}, peeked)
+	assert.Equal(t, []string{"pear", "plum"}, result)
+}
+
+func TestStream_Trace(t *testing.T) {
+	buf := &bytes.Buffer{}
+	SetTraceOutput(buf)
+	defer SetTraceOutput(os.Stderr)
+
+	count := 0
+	result := From[string](testArray).
+		Filter(func(x string) bool { return strings.HasPrefix(x, "p") }).
+		Trace("prefix", nil).
+		Filter(func(x string) bool { return len(x) == 4 }).
+		Trace("length", func(string) { count++ }).
+		ToArray()
+
+	assert.Equal(t, []string{"pear", "plum"}, result)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "[prefix] peach\n[prefix] pear\n[length] pear\n[prefix] plum\n[length] plum\n[prefix] pineapple\n", buf.String())
+}
+
+func TestStream_Compact(t *testing.T) {
+	arr := MapToPtr[testStruct]([]testStruct{{A: "a"}, {A: "b"}, {A: "c"}})
+	withNils := []*testStruct{nil, arr[0], nil, arr[1], arr[2], nil}
+
+	assert.Equal(t, arr, From[*testStruct](withNils).Compact().ToArray())
+	assert.Empty(t, From[*testStruct]([]*testStruct{nil, nil}).Compact().ToArray())
+
+	assert.Equal(t, []string{"a", "b"}, From[string]([]string{"", "a", "", "b"}).Compact().ToArray())
+}
+
+func TestStream_CountMatch(t *testing.T) {
+	stream := From[string](testArray)
+
+	assert.Equal(t, 4, stream.CountMatch(func(x string) bool { return strings.HasPrefix(x, "p") }))
+	assert.Equal(t, 0, stream.CountMatch(func(x string) bool { return strings.HasPrefix(x, "z") }))
+
+	// The stream is not filtered by the condition
+	assert.Equal(t, len(testArray), stream.Count())
+}
+
+func TestStream_ReservoirSample(t *testing.T) {
+	sample := FromChannel[string](newTestChannel(testArray...)).ReservoirSample(3, rand.New(rand.NewSource(42)))
+	assert.Equal(t, []string{"peach", "kiwi", "banana"}, sample.ToArray())
+
+	// Same seed, same sample
+	again := From[string](testArray).ReservoirSample(3, rand.New(rand.NewSource(42)))
+	assert.Equal(t, sample.ToArray(), again.ToArray())
+
+	assert.Equal(t, 3, From[string](testArray).ReservoirSample(3).Len())
+	assert.Equal(t, []string{"peach", "apple"}, From[string](testArray[:2]).ReservoirSample(3).ToArray())
+	assert.Empty(t, From[string](testArray).ReservoirSample(0).ToArray())
+}
+
+func TestStream_OrderByAll(t *testing.T) {
+	arr := []*testStruct{
+		{A: "pear", B: 2},
+		{A: "apple", B: 3},
+		{A: "kiwi", B: 2},
+		{A: "banana", B: 1},
+		{A: "apple", B: 1},
+	}
+
+	byBThenA := From[*testStruct](arr).OrderByAll(
+		func(a, b *testStruct) int { return a.B - b.B },
+		func(a, b *testStruct) int { return strings.Compare(a.A, b.A) },
+	).ToArray()
+	assert.Equal(t, []*testStruct{arr[4], arr[3], arr[2], arr[0], arr[1]}, byBThenA)
+
+	byADescThenB := From[*testStruct](arr).OrderByAll(
+		func(a, b *testStruct) int { return strings.Compare(b.A, a.A) },
+		func(a, b *testStruct) int { return a.B - b.B },
+	).ToArray()
+	assert.Equal(t, []*testStruct{arr[0], arr[2], arr[3], arr[4], arr[1]}, byADescThenB)
+}
+
+func TestStream_SortStable(t *testing.T) {
+	var arr []*testStruct
+	for i := 0; i < 100; i++ {
+		arr = append(arr, &testStruct{A: testArray[i%len(testArray)], B: i % 3})
+	}
+
+	byB := func(a, b *testStruct) int { return a.B - b.B }
+	byLen := func(a, b *testStruct) int { return len(a.A) - len(b.A) }
+
+	result := From[*testStruct](arr).SortStable(byB).SortStable(byLen, true).ToArray()
+	assert.Len(t, result, len(arr))
+
+	// Ties keep the order of the source
+	for i := 1; i < len(result); i++ {
+		prev, cur := result[i-1], result[i]
+		if prev.B == cur.B && len(prev.A) == len(cur.A) {
+			assert.Less(t, indexOf(arr, prev), indexOf(arr, cur))
+		}
+	}
+
+	// Stable sorts are deterministic
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, result, From[*testStruct](arr).SortStable(byB).SortStable(byLen, true).ToArray())
+	}
+}
+
+func indexOf[T comparable](arr []T, x T) int {
+	for i, v := range arr {
+		if v == x {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestStream_UnionWith(t *testing.T) {
+	result := From[string](testArray).
+		Filter(func(x string) bool { return strings.HasPrefix(x, "p") }).
+		UnionWith([]string{"kiwi", "peach", "mango"}).
+		ToArray()
+	assert.Equal(t, []string{"peach", "pear", "plum", "pineapple", "kiwi", "mango"}, result)
+}
+
+func TestStream_IntersectWith(t *testing.T) {
+	result := From[string](testArray).IntersectWith([]string{"kiwi", "mango", "apple", "kiwi"}).ToArray()
+	assert.Equal(t, []string{"apple", "kiwi"}, result)
+
+	assert.Empty(t, From[string](testArray).IntersectWith(nil).ToArray())
+}
+
+func TestStream_ExceptWith(t *testing.T) {
+	result := From[string](testArray).ExceptWith([]string{"peach", "apple", "mango", "orange"}).ToArray()
+	assert.Equal(t, []string{"pear", "plum", "pineapple", "banana", "kiwi"}, result)
+
+	assert.Equal(t, testArray, From[string](testArray).ExceptWith(nil).ToArray())
+}
+
 func TestStream_DistinctWithSort(t *testing.T) {
 	arr := append(testArray, "apple", "banana", "kiwi", "apple", "banana", "apple")
 	expected := []string{"apple", "banana", "kiwi", "orange", "peach", "pear", "pineapple", "plum"}
@@ -319,3 +583,950 @@ func TestMapToPtr(t *testing.T) {
 	assert.Equal(t, "[]streams.testStruct", reflect.TypeOf(arr).String())
 	assert.Equal(t, "[]*streams.testStruct", reflect.TypeOf(ret).String())
 }
+
+func TestStream_ForEachUntil(t *testing.T) {
+	arr := []int{1, 3, 5, 6, 7, 8, 9}
+	var visited []int
+
+	From[int](arr).ForEachUntil(func(x int) bool {
+		visited = append(visited, x)
+		return x%2 != 0
+	})
+
+	assert.Equal(t, []int{1, 3, 5, 6}, visited)
+}
+
+type testVersion struct {
+	Major int
+	Minor int
+}
+
+func (v testVersion) CompareTo(other testVersion) int {
+	if v.Major != other.Major {
+		return v.Major - other.Major
+	}
+	return v.Minor - other.Minor
+}
+
+func TestComparableOf(t *testing.T) {
+	arr := []testVersion{{2, 1}, {1, 10}, {2, 0}, {1, 2}}
+
+	asc := From[testVersion](arr).Sort(ComparableOf[testVersion]()).ToArray()
+	assert.Equal(t, []testVersion{{1, 2}, {1, 10}, {2, 0}, {2, 1}}, asc)
+
+	desc := From[testVersion](arr).Sort(ComparableOf[testVersion](true)).ToArray()
+	assert.Equal(t, []testVersion{{2, 1}, {2, 0}, {1, 10}, {1, 2}}, desc)
+}
+
+func TestCompareBy(t *testing.T) {
+	arr := []string{"kiwi", "banana", "fig", "apple"}
+	byLen := CompareBy[string](func(a, b string) bool { return len(a) < len(b) })
+
+	sorted := From[string](arr).Sort(byLen).ToArray()
+	assert.Equal(t, []string{"fig", "kiwi", "apple", "banana"}, sorted)
+}
+
+func TestToMapFromPairs(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+
+	result := ToMapFromPairs[string, int](
+		FromMap[string, int](m).
+			Filter(func(pair *KeyValuePair[string, int]) bool {
+				return pair.Value%2 == 0
+			}))
+
+	assert.Equal(t, 2, result.Len())
+	assert.Equal(t, map[string]int{"b": 2, "d": 4}, result.ToMap())
+}
+
+func TestUnzip(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+
+	keys, values := Unzip[string, int](
+		SortByKey[string, int](FromMap[string, int](m), strings.Compare).
+			Filter(func(pair *KeyValuePair[string, int]) bool {
+				return pair.Value > 1
+			}))
+
+	assert.Equal(t, []string{"b", "c", "d"}, keys.ToArray())
+	assert.Equal(t, []int{2, 3, 4}, values.ToArray())
+
+	keys, values = Unzip[string, int](FromMap[string, int](map[string]int{}))
+	assert.Equal(t, 0, keys.Len())
+	assert.Equal(t, 0, values.Len())
+}
+
+func TestStream_OnError(t *testing.T) {
+	i := 0
+	source := func() (int, bool, error) {
+		i++
+		switch {
+		case i > 5:
+			return 0, false, nil
+		case i == 3:
+			return 0, true, fmt.Errorf("failed to read element %d", i)
+		}
+		return i, true, nil
+	}
+
+	var errs []error
+	var seen []int
+	FromFunc[int](source).
+		OnError(func(err error) {
+			// The error is reported as it happens, after the elements read before it
+			assert.Equal(t, []int{1, 2}, seen)
+			errs = append(errs, err)
+		}).
+		ForEach(func(x int) {
+			seen = append(seen, x)
+		})
+
+	assert.Equal(t, []int{1, 2, 4, 5}, seen)
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "failed to read element 3")
+
+	// A failure that does not produce an element ends the stream
+	j := 0
+	var fatal error
+	result := FromFunc[int](func() (int, bool, error) {
+		j++
+		if j == 3 {
+			return 0, false, errors.New("connection lost")
+		}
+		return j, true, nil
+	}).OnError(func(err error) { fatal = err }).ToArray()
+
+	assert.Equal(t, []int{1, 2}, result)
+	assert.EqualError(t, fatal, "connection lost")
+}
+
+func TestStream_OnError_UsesStream(t *testing.T) {
+	i := 0
+	stream := FromFunc[int](func() (int, bool, error) {
+		i++
+		switch {
+		case i > 5:
+			return 0, false, nil
+		case i == 3:
+			return 0, true, errors.New("failed")
+		}
+		return i, true, nil
+	}, 1)
+
+	var first, count int
+	stream.OnError(func(error) {
+		// The handlers do not run while the source is locked, so they can use the stream
+		first = stream.First()
+		count = stream.Count()
+	})
+
+	done := make(chan []int)
+	go func() {
+		done <- stream.ToArray()
+	}()
+
+	select {
+	case result := <-done:
+		assert.Equal(t, []int{1, 2, 4, 5}, result)
+		assert.Equal(t, 1, first)
+		assert.Equal(t, 4, count)
+	case <-time.After(5 * time.Second):
+		t.Fatal("using the stream from an error handler did not return")
+	}
+}
+
+func newTestChannel[T any](items ...T) <-chan T {
+	ch := make(chan T, len(items))
+	for _, item := range items {
+		ch <- item
+	}
+	close(ch)
+	return ch
+}
+
+func TestFromMultiMap(t *testing.T) {
+	m := map[string][]int{
+		"a": {1, 2, 3},
+		"b": {4},
+		"c": {},
+		"d": {5, 6},
+	}
+
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5, 6}, FromMultiMap[string, int](m).ToArray())
+
+	even := FromMultiMap[string, int](m).
+		Filter(func(x int) bool { return x%2 == 0 }).
+		Sort(ComparableFn[int]()).
+		ToArray()
+	assert.Equal(t, []int{2, 4, 6}, even)
+	assert.Empty(t, FromMultiMap[string, int](nil).ToArray())
+}
+
+func TestStream_LastFromChannel(t *testing.T) {
+	arr := []string{"peach", "apple", "pear", "plum", "pineapple", "banana", "kiwi", "orange"}
+	stream := FromChannel[string](newTestChannel(arr...))
+
+	assert.Equal(t, "orange", stream.Last())
+	assert.Equal(t, "kiwi", stream.AtReverse(1))
+	assert.Equal(t, 8, stream.Count())
+
+	filtered := FromChannel[string](newTestChannel(arr...)).
+		Filter(func(x string) bool {
+			return strings.HasPrefix(x, "p")
+		})
+
+	assert.Equal(t, "pineapple", filtered.Last())
+	assert.Equal(t, "peach", filtered.First())
+
+	empty := FromChannel[string](newTestChannel[string]())
+	assert.Equal(t, "some-value", empty.Last("some-value"))
+}
+
+func TestStream_ParallelDistinct(t *testing.T) {
+	var arr []int
+	for i := 0; i < 1000; i++ {
+		arr = append(arr, i%10)
+	}
+
+	result := From[int](arr, -1).Distinct().ToArray()
+	assert.Len(t, result, 10)
+
+	sorted := From[int](arr).SetThreads(4).Distinct().Sort(ComparableFn[int]()).ToArray()
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, sorted)
+
+	filtered := From[int](arr).SetThreads(4).Filter(func(x int) bool { return x > 4 }).Distinct().ToArray()
+	assert.Len(t, filtered, 5)
+}
+
+func TestStream_Explain(t *testing.T) {
+	processed := false
+	stream := From[string]([]string{"peach", "apple", "pear"}).
+		Filter(func(x string) bool {
+			processed = true
+			return strings.HasPrefix(x, "p")
+		}).
+		Sort(strings.Compare, true).
+		Distinct()
+
+	assert.Equal(t, "source: 3 elements, filters: 1, sorts: [desc], distinct: true, threads: 1", stream.Explain())
+	assert.False(t, processed)
+
+	lazy := FromChannel[int](newTestChannel(1, 2, 3), 2)
+	assert.Equal(t, "source: unknown length, filters: 0, sorts: [], distinct: false, threads: 2", lazy.Explain())
+}
+
+type testRangeIterable struct {
+	from, to int
+}
+
+func (r *testRangeIterable) Iterator() IIterator[int] {
+	return &testRangeIterator{current: r.from, to: r.to}
+}
+
+func (r *testRangeIterable) ForEach(f IterFunc[int]) {
+	r.Iterator().ForEachRemaining(f)
+}
+
+type testRangeIterator struct {
+	current, to int
+}
+
+func (r *testRangeIterator) Current() int  { return r.current }
+func (r *testRangeIterator) HasNext() bool { return r.current < r.to }
+func (r *testRangeIterator) Next() int     { r.MoveNext(); return r.current }
+func (r *testRangeIterator) MoveNext() bool {
+	if !r.HasNext() {
+		return false
+	}
+	r.current++
+	return r.HasNext()
+}
+func (r *testRangeIterator) Skip(n int) IIterator[int] {
+	for i := 0; i < n; i++ {
+		r.MoveNext()
+	}
+	return r
+}
+func (r *testRangeIterator) ForEachRemaining(f IterFunc[int]) {
+	for x := r.Current(); r.HasNext(); x = r.Next() {
+		f(x)
+	}
+}
+
+func TestFromIterable(t *testing.T) {
+	stream := FromIterable[int](&testRangeIterable{from: 0, to: 10}).
+		Filter(func(x int) bool {
+			return x%3 == 0
+		})
+
+	assert.Equal(t, []int{0, 3, 6, 9}, stream.ToArray())
+	assert.Equal(t, 4, stream.Count())
+	assert.Equal(t, 9, stream.Last())
+
+	assert.Equal(t, 10, From[int](&testRangeIterable{from: 0, to: 10}).Count())
+}
+
+func TestStream_SortKeepsSource(t *testing.T) {
+	arr := []int{3, 1, 2}
+	sorted := From[int](arr).Sort(ComparableFn[int]()).ToArray()
+
+	assert.Equal(t, []int{1, 2, 3}, sorted)
+	assert.Equal(t, []int{3, 1, 2}, arr)
+}
+
+func TestStream_ToListAllocations(t *testing.T) {
+	arr := make([]int, 1000)
+	for i := range arr {
+		arr[i] = i % 100
+	}
+	even := func(x int) bool { return x%2 == 0 }
+
+	for name, stream := range map[string]IStream[int]{
+		"Filter":   From[int](arr).Filter(even),
+		"Distinct": From[int](arr).Distinct(),
+	} {
+		collectionAllocs := testing.AllocsPerRun(10, func() { stream.ToCollection() })
+		listAllocs := testing.AllocsPerRun(10, func() { stream.ToList() })
+
+		// The processed result is already a list, so it is returned without copying it
+		assert.Equal(t, collectionAllocs, listAllocs, name)
+	}
+
+	assert.Equal(t, 100, From[int](arr).Distinct().ToList().Len())
+	assert.Equal(t, []int{0, 1, 2, 3}, From[int](arr[:4]).Distinct().ToList().ToArray())
+}
+
+func BenchmarkStream_ToList(b *testing.B) {
+	arr := make([]int, 10000)
+	for i := range arr {
+		arr[i] = i % 1000
+	}
+
+	b.Run("Filter", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			From[int](arr).Filter(func(x int) bool { return x%2 == 0 }).ToList()
+		}
+	})
+
+	b.Run("Distinct", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			From[int](arr).Distinct().ToList()
+		}
+	})
+}
+
+func BenchmarkStream_Distinct(b *testing.B) {
+	arr := make([]int, 100000)
+	for i := range arr {
+		arr[i] = i
+	}
+
+	// Baseline of a distinct set which grows without a capacity hint
+	b.Run("NoHint", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			set := NewSet[int]()
+			for _, x := range arr {
+				set.Add(x)
+			}
+		}
+	})
+
+	b.Run("Distinct", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			From[int](arr).Distinct().ToCollection()
+		}
+	})
+}
+
+func TestStream_ToSortedList(t *testing.T) {
+	expected := []string{"plum", "pineapple", "pear", "peach", "orange", "kiwi", "banana", "apple"}
+
+	assert.Equal(t, expected, From[string](testArray).ToSortedList(strings.Compare, true).ToArray())
+	assert.Equal(t, expected, From[string](testArray).Sort(strings.Compare).ToSortedArray(strings.Compare, true))
+
+	// Staged sorts only break ties
+	byLength := func(a, b string) int { return len(a) - len(b) }
+	result := From[string](testArray).Sort(strings.Compare).ToSortedArray(byLength)
+	assert.Equal(t, []string{"kiwi", "pear", "plum", "apple", "peach", "banana", "orange", "pineapple"}, result)
+
+	// The source is not modified
+	assert.Equal(t, "peach", testArray[0])
+}
+
+func TestStream_DistinctByHash(t *testing.T) {
+	arr := []*testStruct{
+		{A: "apple", B: 1},
+		{A: "pear", B: 2},
+		{A: "apple", B: 3},
+		{A: "kiwi", B: 4},
+		{A: "pear", B: 5},
+	}
+
+	hash := func(x *testStruct) uint64 {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(x.A))
+		return h.Sum64()
+	}
+	eq := func(a, b *testStruct) bool { return a.A == b.A }
+
+	result := From[*testStruct](arr).DistinctByHash(hash, eq).ToArray()
+	assert.Equal(t, []*testStruct{arr[0], arr[1], arr[3]}, result)
+
+	// Collisions are resolved with the equality function
+	collisions := From[*testStruct](arr).DistinctByHash(func(*testStruct) uint64 { return 0 }, eq).ToArray()
+	assert.Equal(t, result, collisions)
+}
+
+func TestStream_ToOrderedSet(t *testing.T) {
+	arr := []string{"pear", "apple", "pear", "kiwi", "apple", "banana"}
+
+	result := From[string](arr).ToOrderedSet()
+	assert.Equal(t, []string{"pear", "apple", "kiwi", "banana"}, result.ToArray())
+
+	val, ok := result.Index(2)
+	assert.True(t, ok)
+	assert.Equal(t, "kiwi", val)
+
+	assert.False(t, result.Add("pear"))
+	assert.True(t, result.Add("plum"))
+	assert.True(t, result.RemoveAt(0))
+	assert.Equal(t, []string{"apple", "kiwi", "banana", "plum"}, result.ToArray())
+
+	assert.Equal(t, []string{"kiwi", "plum"}, result.Except(NewList[string]([]string{"apple", "banana"})).ToArray())
+}
+
+func TestSetBufferPooling(t *testing.T) {
+	SetBufferPooling(true)
+	defer SetBufferPooling(false)
+
+	arr := make([]int, 1000)
+	for i := range arr {
+		arr[i] = len(arr) - i
+	}
+
+	stream := From[int](arr).Filter(func(x int) bool { return x%2 == 0 }).Sort(ComparableFn[int]())
+	result := stream.ToArray()
+
+	for i := 0; i < 5; i++ {
+		var iterated []int
+		stream.ForEach(func(x int) { iterated = append(iterated, x) })
+		assert.Equal(t, result, iterated)
+	}
+
+	// Results returned by terminal operations are not reused
+	assert.Len(t, result, 500)
+	for i, x := range result {
+		assert.Equal(t, (i+1)*2, x)
+	}
+
+	parallel := From[int](arr, 4).Filter(func(x int) bool { return x%2 == 0 }).Sort(ComparableFn[int]())
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, result, parallel.ToArray())
+	}
+}
+
+func BenchmarkSetBufferPooling(b *testing.B) {
+	arr := make([]int, 10000)
+	for i := range arr {
+		arr[i] = rand.Intn(len(arr))
+	}
+
+	run := func(b *testing.B) {
+		b.ReportAllocs()
+		sum := 0
+		for i := 0; i < b.N; i++ {
+			From[int](arr).
+				Filter(func(x int) bool { return x%2 == 0 }).
+				Sort(ComparableFn[int]()).
+				ForEach(func(x int) { sum += x })
+		}
+	}
+
+	b.Run("Disabled", run)
+	b.Run("Enabled", func(b *testing.B) {
+		SetBufferPooling(true)
+		defer SetBufferPooling(false)
+		run(b)
+	})
+}
+
+func TestStream_ToDistinctWithSort(t *testing.T) {
+	arr := append([]string{"apple", "banana", "kiwi", "apple", "banana"}, testArray...)
+
+	result := From[string](arr).Distinct().Sort(strings.Compare).ToDistinct()
+
+	assert.IsType(t, &set[string]{}, result)
+	assert.Equal(t, len(testArray), result.Len())
+	assert.True(t, result.Contains(testArray...))
+	assert.False(t, result.Add("apple"))
+
+	// The stream is not marked as distinct
+	stream := From[string](arr)
+	assert.Equal(t, len(testArray), stream.ToDistinct().Len())
+	assert.Equal(t, len(arr), stream.Count())
+
+	// Sets created by transforms are returned as is, but the source of the stream is not
+	union := From[string]([]string{"apple", "kiwi"}).UnionWith([]string{"kiwi", "plum"}).ToDistinct()
+	assert.IsType(t, &orderedSet[string]{}, union)
+	assert.ElementsMatch(t, []string{"apple", "kiwi", "plum"}, union.ToArray())
+	source := NewSet[string]()
+	source.Add("apple")
+	distinct := FromCollection[string](source).ToDistinct()
+	distinct.Add("kiwi")
+	assert.Equal(t, 1, source.Len())
+}
+
+func TestStream_AssumeSorted(t *testing.T) {
+	arr := make([]int, 1024)
+	for i := range arr {
+		arr[i] = i * 2
+	}
+
+	comparisons := 0
+	cmp := func(a, b int) int {
+		comparisons++
+		return a - b
+	}
+
+	stream := From[int](arr).Sort(cmp).AssumeSorted()
+
+	assert.True(t, stream.Contains(512))
+	assert.False(t, stream.Contains(513))
+	assert.Greater(t, comparisons, 0)
+	assert.Less(t, comparisons, 50)
+
+	comparisons = 0
+	assert.Equal(t, 2046, stream.Last())
+	assert.Equal(t, 0, comparisons)
+
+	filtered := From[int]([]int{9, 7, 5, 3, 1}).
+		Sort(ComparableFn[int](), true).
+		AssumeSorted().
+		Filter(func(x int) bool { return x > 2 })
+
+	assert.Equal(t, []int{9, 7, 5, 3}, filtered.ToArray())
+	assert.True(t, filtered.Contains(3))
+	assert.False(t, filtered.Contains(1))
+
+	comparisons = 0
+	distinct := From[int]([]int{1, 1, 2, 3, 3, 3, 5}).Sort(cmp).AssumeSorted().Distinct()
+	assert.Equal(t, []int{1, 2, 3, 5}, distinct.ToArray())
+	assert.Equal(t, 0, comparisons)
+	assert.True(t, distinct.Contains(5))
+	assert.False(t, distinct.Contains(4))
+}
+
+func TestStream_DistinctBounded(t *testing.T) {
+	arr := []string{"a", "b", "a", "c", "d", "e", "a", "e"}
+
+	result := From[string](arr).DistinctBounded(3).ToArray()
+	assert.Equal(t, []string{"a", "b", "c", "d", "e", "a"}, result)
+
+	assert.Equal(t, arr, From[string](arr).DistinctBounded(0).ToArray())
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, From[string](arr).DistinctBounded(10).ToArray())
+}
+
+// newSlowTestChannel produces `count` elements, one every `delay`. The producer stops once the test finishes, so it does
+// not leak when the stream stops reading before the channel is closed.
+func newSlowTestChannel(t *testing.T, count int, delay time.Duration) <-chan int {
+	ch := make(chan int)
+	done := make(chan struct{})
+	t.Cleanup(func() { close(done) })
+
+	go func() {
+		defer close(ch)
+		for i := 0; i < count; i++ {
+			select {
+			case <-time.After(delay):
+			case <-done:
+				return
+			}
+			select {
+			case ch <- i:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+func TestStream_WithTimeout(t *testing.T) {
+	var visited []int
+	stream := FromChannel[int](newSlowTestChannel(t, 20, 10*time.Millisecond)).WithTimeout(45 * time.Millisecond)
+	stream.ForEach(func(x int) {
+		visited = append(visited, x)
+	})
+
+	assert.NotEmpty(t, visited)
+	assert.Less(t, len(visited), 20)
+	assert.Equal(t, ErrTimeout, stream.Err())
+
+	filtered := FromChannel[int](newSlowTestChannel(t, 20, 10*time.Millisecond)).
+		Filter(func(x int) bool { return x%2 == 0 }).
+		WithTimeout(45 * time.Millisecond)
+
+	assert.Less(t, filtered.Count(), 10)
+	assert.Equal(t, ErrTimeout, filtered.Err())
+
+	completed := FromChannel[int](newSlowTestChannel(t, 3, time.Millisecond)).WithTimeout(time.Second)
+	assert.Equal(t, 3, completed.Count())
+	assert.NoError(t, completed.Err())
+}
+
+func TestStream_String(t *testing.T) {
+	stream := From[string](testArray)
+	assert.Equal(t, "Stream[string]{count:8, filters:0, sorted:false}", stream.String())
+	assert.Equal(t, "Stream[string][peach, apple, pear, ...]", stream.PreviewString(3))
+
+	stream.
+		Filter(func(x string) bool { return strings.HasPrefix(x, "p") }).
+		Except(func(x string) bool { return x == "pear" }).
+		Sort(strings.Compare)
+
+	assert.Equal(t, "Stream[string]{count:?, filters:2, sorted:true}", stream.String())
+	assert.Equal(t, "Stream[string][peach, pineapple, plum]", stream.PreviewString(10))
+	assert.Equal(t, "Stream[int][]", From[int]([]int{}).PreviewString(2))
+}
+
+func TestStream_JoinToString(t *testing.T) {
+	assert.Equal(t, "[peach, apple]", From[string](testArray[:2]).JoinToString(", ", "[", "]", nil))
+	assert.Equal(t, "[]", From[string]([]string{}).JoinToString(", ", "[", "]", nil))
+
+	result := From[*testStruct]([]*testStruct{{A: "pear", B: 2}, {A: "kiwi", B: 5}}).
+		JoinToString(" | ", "{", "}", func(x *testStruct) string { return fmt.Sprintf("%s=%d", x.A, x.B) })
+	assert.Equal(t, "{pear=2 | kiwi=5}", result)
+	assert.Equal(t, "1;2;3", From[int]([]int{1, 2, 3}).JoinToString(";", "", "", nil))
+}
+
+func TestStream_WriteTo(t *testing.T) {
+	buffer := new(bytes.Buffer)
+
+	n, err := From[string](testArray).
+		Filter(func(x string) bool { return strings.HasPrefix(x, "p") }).
+		WriteTo(buffer, strings.ToUpper)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "PEACH\nPEAR\nPLUM\nPINEAPPLE\n", buffer.String())
+	assert.Equal(t, int64(buffer.Len()), n)
+
+	buffer.Reset()
+	_, err = From[int]([]int{1, 2, 3}).WriteTo(buffer, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "1\n2\n3\n", buffer.String())
+}
+
+func TestSetDefaultThreads(t *testing.T) {
+	defer SetDefaultThreads(1)
+
+	assert.Equal(t, 1, DefaultThreads())
+	SetDefaultThreads(4)
+	assert.Equal(t, 4, DefaultThreads())
+
+	stream := From[string](testArray)
+	assert.Contains(t, stream.Explain(), "threads: 4")
+	assert.Equal(t, testArray, stream.ToArray())
+
+	filtered := From[string](testArray).Filter(func(x string) bool { return strings.HasPrefix(x, "p") })
+	assert.Contains(t, filtered.Explain(), "threads: 4")
+	assert.ElementsMatch(t, []string{"peach", "pear", "plum", "pineapple"}, filtered.ToArray())
+
+	assert.Contains(t, From[string](testArray, 2).Explain(), "threads: 2")
+
+	SetDefaultThreads(-1)
+	assert.Equal(t, getCores(-1), DefaultThreads())
+}
+
+func TestStream_Iterator(t *testing.T) {
+	stream := From[string](testArray).
+		Filter(func(x string) bool { return strings.Contains(x, "a") }).
+		Sort(strings.Compare)
+
+	var result []string
+	iterator := stream.Iterator()
+	for x := iterator.Current(); iterator.HasNext(); x = iterator.Next() {
+		result = append(result, x)
+	}
+
+	assert.Equal(t, stream.ToArray(), result)
+	assert.False(t, From[int]([]int{}).Iterator().HasNext())
+}
+
+func TestStream_EqualTo(t *testing.T) {
+	reversed := From[string](testArray).Sort(strings.Compare, true)
+
+	assert.True(t, From[string](testArray).EqualTo(FromChannel[string](newTestChannel(testArray...))))
+	assert.True(t, From[string](testArray).EqualAsSet(From[string](testArray)))
+
+	assert.False(t, From[string](testArray).EqualTo(reversed))
+	assert.True(t, From[string](testArray).EqualAsSet(reversed))
+
+	shorter := From[string](testArray).Filter(func(x string) bool { return x != "orange" })
+	assert.False(t, From[string](testArray).EqualTo(shorter))
+	assert.False(t, shorter.EqualTo(From[string](testArray)))
+	assert.False(t, From[string](testArray).EqualAsSet(shorter))
+
+	assert.True(t, From[int]([]int{}).EqualTo(From[int]([]int{})))
+}
+
+func TestStream_CountAtLeast(t *testing.T) {
+	visited := 0
+	stream := FromIterable[int](&testRangeIterable{from: 0, to: math.MaxInt64}).
+		Filter(func(x int) bool {
+			visited++
+			return x%2 == 0
+		})
+
+	assert.True(t, stream.CountAtLeast(5))
+	assert.Equal(t, 9, visited)
+
+	assert.True(t, From[string](testArray).CountAtLeast(8))
+	assert.False(t, From[string](testArray).CountAtLeast(9))
+	assert.False(t, From[string](testArray).Distinct().Filter(func(x string) bool { return x != "kiwi" }).CountAtLeast(8))
+	assert.True(t, From[string](testArray).CountAtLeast(0))
+}
+
+func TestStream_ForEachErr(t *testing.T) {
+	var processed []string
+	expectedErr := fmt.Errorf("unable to process pear")
+
+	err := From[string](testArray).ForEachErr(func(x string) error {
+		if x == "pear" {
+			return expectedErr
+		}
+		processed = append(processed, x)
+		return nil
+	})
+
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, []string{"peach", "apple"}, processed)
+
+	processed = nil
+	err = From[string](testArray).ForEachErr(func(x string) error {
+		processed = append(processed, x)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, testArray, processed)
+}
+
+func TestStream_Tap(t *testing.T) {
+	var log []string
+
+	result := From[string](testArray).
+		Filter(func(x string) bool { return strings.HasPrefix(x, "p") }).
+		Tap(func(arr []string) {
+			log = append(log, fmt.Sprintf("after prefix filter: %d", len(arr)))
+		}).
+		Filter(func(x string) bool { return len(x) > 4 }).
+		Tap(func(arr []string) {
+			log = append(log, fmt.Sprintf("after length filter: %d", len(arr)))
+		}).
+		ToArray()
+
+	assert.Equal(t, []string{"peach", "pineapple"}, result)
+	assert.Equal(t, []string{"after prefix filter: 4", "after length filter: 2"}, log)
+}
+
+func TestStream_Cycle(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 1, 2, 1, 2}, From[int]([]int{1, 2}).Cycle(3).ToArray())
+
+	filtered := From[int]([]int{3, 1, 2, 4}).
+		Filter(func(x int) bool { return x < 4 }).
+		Sort(ComparableFn[int]()).
+		Cycle(2)
+	assert.Equal(t, []int{1, 2, 3, 1, 2, 3}, filtered.ToArray())
+	assert.Equal(t, 6, filtered.Count())
+
+	var infinite []int
+	From[int]([]int{1, 2}).Cycle(0).ForEachUntil(func(x int) bool {
+		infinite = append(infinite, x)
+		return len(infinite) < 5
+	})
+	assert.Equal(t, []int{1, 2, 1, 2, 1}, infinite)
+	assert.True(t, From[int]([]int{1, 2}).Cycle(-1).CountAtLeast(100))
+	assert.Empty(t, From[int]([]int{}).Cycle(0).ToArray())
+}
+
+func TestStream_AppendPrepend(t *testing.T) {
+	result := From[string](testArray).
+		Filter(func(x string) bool { return strings.HasPrefix(x, "p") }).
+		Sort(ComparableFn[string]()).
+		Prepend("BEGIN").
+		Append("END").
+		ToArray()
+	assert.Equal(t, []string{"BEGIN", "peach", "pear", "pineapple", "plum", "END"}, result)
+
+	// Lazy sources
+	ch := newTestChannel(testArray[:3]...)
+	lazy := FromChannel[string](ch).
+		Filter(func(x string) bool { return x != "apple" }).
+		Prepend("BEGIN").
+		Append("END").
+		ToArray()
+	assert.Equal(t, []string{"BEGIN", "peach", "pear", "END"}, lazy)
+
+	assert.Equal(t, []int{0, 1, 2}, From[int]([]int{}).Append(1, 2).Prepend(0).ToArray())
+}
+
+func TestStream_FilterIndexed(t *testing.T) {
+	even := func(i int, _ string) bool { return i%2 == 0 }
+
+	assert.Equal(t, []string{"peach", "pear", "pineapple", "kiwi"}, From[string](testArray).FilterIndexed(even).ToArray())
+
+	// The index is the position in the source, even if other filters are applied first
+	result := From[string](testArray).
+		Filter(func(x string) bool { return x != "peach" }).
+		FilterIndexed(even).
+		ToArray()
+	assert.Equal(t, []string{"pear", "pineapple", "kiwi"}, result)
+
+	parallel := From[string](testArray, 3).FilterIndexed(even).ToArray()
+	assert.ElementsMatch(t, []string{"peach", "pear", "pineapple", "kiwi"}, parallel)
+}
+
+func TestStream_Limit(t *testing.T) {
+	assert.Equal(t, []string{"peach", "apple", "pear"}, From[string](testArray).Limit(3).ToArray())
+	assert.Equal(t, []string{"apple", "banana"}, From[string](testArray).Sort(strings.Compare).Limit(2).ToArray())
+	assert.Equal(t, testArray, From[string](testArray).Limit(20).ToArray())
+	assert.Empty(t, From[string](testArray).Limit(0).ToArray())
+	assert.Equal(t, []int{1, 2, 1, 2, 1}, From[int]([]int{1, 2}).Cycle(0).Limit(5).ToArray())
+}
+
+func TestStream_Limit_Presized(t *testing.T) {
+	arr := make([]int, 10000)
+	for i := range arr {
+		arr[i] = i
+	}
+
+	result := From[int](arr).Filter(func(x int) bool { return x%2 == 0 }).Limit(10).ToArray()
+	assert.Equal(t, []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18}, result)
+	assert.Equal(t, 10, cap(result))
+
+	// The capacity is never larger than the source
+	small := From[int](arr[:5]).Filter(func(x int) bool { return x > 0 }).Limit(10).ToArray()
+	assert.Equal(t, []int{1, 2, 3, 4}, small)
+	assert.Equal(t, 5, cap(small))
+}
+
+func BenchmarkStream_Limit(b *testing.B) {
+	arr := make([]int, 100000)
+	for i := range arr {
+		arr[i] = i
+	}
+	even := func(x int) bool { return x%2 == 0 }
+
+	// Limit presizes the result, while FirstN grows it as the elements are found
+	b.Run("Limit", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			From[int](arr).Filter(even).Limit(500).ToArray()
+		}
+	})
+
+	b.Run("FirstN", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			From[int](arr).Filter(even).FirstN(500).ToArray()
+		}
+	})
+}
+
+func TestStream_Limit_Infinite(t *testing.T) {
+	i := 0
+	generated := 0
+	stream := Generate[int](func() int {
+		generated++
+		i++
+		return i
+	})
+
+	done := make(chan []int)
+	go func() {
+		done <- stream.Filter(func(x int) bool { return x%3 == 0 }).Limit(5).ToArray()
+	}()
+
+	select {
+	case result := <-done:
+		assert.Equal(t, []int{3, 6, 9, 12, 15}, result)
+		assert.Equal(t, 15, generated)
+	case <-time.After(5 * time.Second):
+		t.Fatal("limiting an infinite source did not return")
+	}
+
+	// The generated elements are buffered, so processing the stream again does not generate them again
+	assert.Equal(t, []int{3, 6, 9}, stream.Limit(3).ToArray())
+	assert.Equal(t, 15, generated)
+}
+
+func TestStream_EveryNth(t *testing.T) {
+	arr := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	assert.Equal(t, []int{0, 2, 4, 6, 8}, From[int](arr).EveryNth(2).ToArray())
+	assert.Equal(t, []int{0, 3, 6, 9}, From[int](arr).EveryNth(3).ToArray())
+	assert.Equal(t, arr, From[int](arr).EveryNth(1).ToArray())
+	assert.Equal(t, []int{9, 7, 5, 3, 1}, From[int](arr).Sort(ComparableFn[int](), true).EveryNth(2).ToArray())
+
+	lazy := FromChannel[int](newTestChannel(arr...)).EveryNth(4).ToArray()
+	assert.Equal(t, []int{0, 4, 8}, lazy)
+}
+
+func TestStream_SkipEveryNth(t *testing.T) {
+	arr := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	assert.Equal(t, []int{1, 3, 5, 7, 9}, From[int](arr).SkipEveryNth(2).ToArray())
+	assert.Equal(t, []int{1, 2, 4, 5, 7, 8}, From[int](arr).SkipEveryNth(3).ToArray())
+	assert.Empty(t, From[int](arr).SkipEveryNth(1).ToArray())
+	assert.Equal(t, arr, From[int](arr).SkipEveryNth(0).ToArray())
+
+	lazy := FromChannel[int](newTestChannel(arr...)).SkipEveryNth(4).ToArray()
+	assert.Equal(t, []int{1, 2, 3, 5, 6, 7, 9}, lazy)
+}
+
+func TestStream_FirstN(t *testing.T) {
+	assert.Equal(t, []string{"peach", "apple", "pear"}, From[string](testArray).FirstN(3).ToArray())
+	assert.Equal(t, []string{"apple", "banana", "kiwi"}, From[string](testArray).Sort(strings.Compare).FirstN(3).ToArray())
+	assert.Equal(t, testArray, From[string](testArray).FirstN(20).ToArray())
+	assert.Empty(t, From[string](testArray).FirstN(0).ToArray())
+
+	// Only the elements needed are read from infinite sources
+	assert.Equal(t, []int{1, 2, 3}, From[int]([]int{1, 2, 3}).Cycle(0).FirstN(3).ToArray())
+}
+
+func TestStream_LastN(t *testing.T) {
+	assert.Equal(t, []string{"banana", "kiwi", "orange"}, From[string](testArray).LastN(3).ToArray())
+	assert.Equal(t, []string{"pear", "pineapple", "plum"}, From[string](testArray).Sort(strings.Compare).LastN(3).ToArray())
+	assert.Equal(t, testArray, From[string](testArray).LastN(20).ToArray())
+	assert.Empty(t, From[string](testArray).LastN(0).ToArray())
+}
+
+func TestStream_SkipLast(t *testing.T) {
+	assert.Equal(t, []string{"peach", "apple", "pear", "plum", "pineapple", "banana"}, From[string](testArray).SkipLast(2).ToArray())
+	assert.Equal(t, []string{"peach", "pear"}, From[string](testArray).
+		Filter(func(x string) bool { return strings.HasPrefix(x, "p") }).
+		SkipLast(2).
+		ToArray())
+	assert.Empty(t, From[string](testArray).SkipLast(len(testArray)).ToArray())
+	assert.Equal(t, testArray, From[string](testArray).SkipLast(0).ToArray())
+
+	lazy := FromChannel[int](newTestChannel(1, 2, 3, 4, 5)).SkipLast(2).ToArray()
+	assert.Equal(t, []int{1, 2, 3}, lazy)
+}
+
+func TestStream_LimitLast(t *testing.T) {
+	assert.Equal(t, []string{"kiwi", "orange"}, From[string](testArray).LimitLast(2).ToArray())
+	assert.Equal(t, []string{"pineapple", "plum"}, From[string](testArray).
+		Sort(strings.Compare).
+		LimitLast(2).
+		ToArray())
+	assert.Equal(t, testArray, From[string](testArray).LimitLast(20).ToArray())
+	assert.Empty(t, From[string](testArray).LimitLast(0).ToArray())
+
+	lazy := FromChannel[int](newTestChannel(1, 2, 3, 4, 5)).LimitLast(2).ToArray()
+	assert.Equal(t, []int{4, 5}, lazy)
+}
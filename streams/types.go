@@ -1,5 +1,11 @@
 package streams
 
+import (
+	"io"
+	"math/rand"
+	"time"
+)
+
 // IIterator defines the contract to be used to iterate over a set.
 //
 //	Usage:
@@ -89,6 +95,14 @@ type ICollection[T comparable] interface {
 
 	// IsEmpty indicates whether this collection has any elements
 	IsEmpty() bool
+
+	// Equals indicates whether this collection and the provided one contain the same elements in the same order, as
+	// produced by their iterators. For unordered collections such as sets created with `NewSet`, use `EqualsUnordered`.
+	Equals(other ICollection[T]) bool
+
+	// EqualsUnordered indicates whether this collection and the provided one contain the same elements regardless of
+	// their order, where every element has to appear the same amount of times in both collections.
+	EqualsUnordered(other ICollection[T]) bool
 }
 
 // IList represents a collection of elements of type T, similar to ICollection[T], but it includes operations that require an index
@@ -106,9 +120,34 @@ type IList[T comparable] interface {
 	//                   in the list.
 	RemoveAt(index int, keepOrder ...bool) bool
 
+	// SetIndex replaces the element at the provided index with the provided value. Returns false if the index is out of
+	// range or the list does not support replacing the element, such as sets which already contain the value.
+	SetIndex(index int, value T) bool
+
+	// Fill replaces every element of the list with the provided value, keeping its length. Returns false if the list
+	// does not support it, such as sets and maps whose elements have to be unique.
+	Fill(value T) bool
+
+	// EnsureCapacity grows the capacity of the list, if necessary, so it can hold at least `n` elements without
+	// reallocating, useful before adding a known amount of elements.
+	EnsureCapacity(n int)
+
+	// TrimToSize reduces the capacity of the list to its length, releasing the excess of memory of long-lived lists
+	// which were built incrementally.
+	TrimToSize()
+
 	// Distinct returns a set of all unique values in this collection
 	Distinct() ISet[T]
 
+	// Union returns a new set with the unique values contained by this collection or the provided one
+	Union(other ICollection[T]) ISet[T]
+
+	// Intersect returns a new set with the unique values contained by both this collection and the provided one
+	Intersect(other ICollection[T]) ISet[T]
+
+	// Except returns a new set with the unique values of this collection which are not contained by the provided one
+	Except(other ICollection[T]) ISet[T]
+
 	// Stream returns a sequential Stream with this collection as its source.
 	Stream() IStream[T]
 }
@@ -134,18 +173,165 @@ type IStream[T comparable] interface {
 	// the function (return false) will be filtered when processing the stream
 	Filter(f ConditionalFunc[T]) IStream[T]
 
+	// FilterIndexed appends a filtering function to the stream which also receives the index of the element in the
+	// source of the stream, where any element that does not meet the condition provided by the function (return false)
+	// will be filtered when processing the stream. The index is the original position of the element in the source,
+	// regardless of any other filters applied before.
+	FilterIndexed(f func(i int, v T) bool) IStream[T]
+
 	// Except has the opposite effect than 'Filter'. Appends a filtering function to the stream, where any element that
 	// does not meet the condition provided by the function (return true) will be filtered when processing the stream.
 	Except(f ConditionalFunc[T]) IStream[T]
 
+	// Peek invokes the provided function with every element that reaches this stage of the stream when the stream is
+	// processed, without modifying the elements, mainly for debugging. The function is invoked after the filters staged
+	// before it and before the filters staged after it. If the stream is processed in parallel, the function may be
+	// invoked concurrently.
+	Peek(f IterFunc[T]) IStream[T]
+
+	// Trace works as `Peek`, but also writes every element that reaches this stage to the trace output, prefixed by the
+	// provided label, so multiple stages of a pipeline can be distinguished in the logs. See `SetTraceOutput`. The
+	// function is optional and may be nil.
+	Trace(label string, f IterFunc[T]) IStream[T]
+
+	// Compact appends a filter to the stream which drops the elements equal to the zero value of T, such as nil pointers
+	// in streams of pointers, or empty strings in streams of strings.
+	Compact() IStream[T]
+
 	// Sort sorts the elements in the stream using the provided comparable function.
 	//
 	// - desc:  indicates whether the sorting should be done descendant
 	Sort(f SortFunc[T], desc ...bool) IStream[T]
 
-	// Distinct ensures that the finalizing operation of the stream includes only unique elements
+	// SortStable sorts the elements in the stream using the provided comparable function, like `Sort`, but guarantees
+	// that the elements considered equal by all the staged sort functions keep the order they had before sorting. Once
+	// staged, all the sorts of the stream are applied with a stable sort. Unstable sorts (`Sort`) are faster, but do not
+	// guarantee the order of ties. The order of ties is only deterministic if the stream is processed sequentially.
+	//
+	// - desc:  indicates whether the sorting should be done descendant
+	SortStable(f SortFunc[T], desc ...bool) IStream[T]
+
+	// OrderByAll sorts the elements in the stream using several comparable functions at once, where every function is only
+	// used to break the ties of the previous ones. Equivalent to calling `Sort` once for every function, in order. The
+	// functions sort ascending; invert the result of a function to sort its key descending.
+	OrderByAll(cmps ...SortFunc[T]) IStream[T]
+
+	// AssumeSorted indicates that the source of the stream is already ordered by the sort functions staged with `Sort`,
+	// so processing the stream skips sorting the elements and operations such as `Contains` use a binary search. The
+	// order is only preserved if the stream is processed sequentially, otherwise the elements are sorted as usual.
+	//
+	// NOTE: The order of the source is not verified. If the source is not ordered by the staged sort functions, the
+	// results of the stream will be wrong.
+	AssumeSorted() IStream[T]
+
+	// Distinct ensures that the finalizing operation of the stream includes only unique elements. Sequential streams keep
+	// the first occurrence of every element, in the order they were found.
 	Distinct() IStream[T]
 
+	// DistinctBounded removes the duplicates of the stream using a bounded memory of the most recent unique elements,
+	// evicting the oldest ones once `maxSeen` elements are remembered. Unlike `Distinct`, duplicates are only removed if
+	// they are found within the recent window, so it is meant for an approximate dedup (such as removing repeated lines
+	// in logs) of streams too large to keep every unique element in memory. `maxSeen` <= 0 disables the dedup.
+	DistinctBounded(maxSeen int) IStream[T]
+
+	// Explain returns a human-readable description of the operations staged in the stream (source size, filters, sorts,
+	// distinct and threads), useful for debugging complex pipelines. It does not process the stream.
+	Explain() string
+
+	// String returns a short description of the stream, such as `Stream[int]{count:?, filters:2, sorted:true}`, without
+	// processing it. The count is only known if no filters or distinct are staged and the size of the source is known.
+	String() string
+
+	// PreviewString processes the stream and returns a string with up to the first `n` elements of the result, such as
+	// `Stream[int][1, 2, 3, ...]`. Useful for logging and test failures.
+	PreviewString(n int) string
+
+	// JoinToString processes the stream and joins the string representation of the elements of the result with the
+	// provided separator, enclosed by the prefix and suffix, such as `[a, b, c]`.
+	//
+	//   - sep:     The separator placed between the elements
+	//   - prefix:  Added at the beginning of the result, even if the stream is empty
+	//   - suffix:  Added at the end of the result, even if the stream is empty
+	//   - fmtFn:   Converts every element into a string. If nil, elements are formatted with `fmt.Sprint`
+	JoinToString(sep, prefix, suffix string, fmtFn func(T) string) string
+
+	// WithTimeout sets a deadline for the terminal operations `Count`, `ForEach` and `ForEachUntil`. If the deadline is
+	// reached the operation is aborted, returning the partial results obtained so far, and `Err` reports `ErrTimeout`.
+	// The deadline is verified between elements, which makes it useful over slow lazy sources such as channels.
+	WithTimeout(d time.Duration) IStream[T]
+
+	// Err returns the error produced by the last terminal operation of the stream, such as `ErrTimeout` if the operation
+	// was aborted by the deadline set with `WithTimeout`. Returns nil if the operation completed successfully.
+	Err() error
+
+	// OnError registers a function to be invoked with every error produced by a lazy source of the stream (such as the
+	// ones created with `FromFunc`) as soon as the error happens while the elements are read, which allows long-running
+	// pipelines to report partial failures without waiting for the terminal operation to complete. The functions are
+	// invoked outside the locks of the source, so they may use the stream, such as counting the elements read so far.
+	OnError(f func(error)) IStream[T]
+
+	// Cycle repeats the elements of the resulting stream, in order, the provided amount of times. The repetition is
+	// applied to the result of the staged filters and sorts. If `times` <= 0 the elements are repeated infinitely, in
+	// which case the resulting stream can only be consumed by operations that stop early, such as `ForEachUntil`,
+	// `CountAtLeast` or `First`.
+	Cycle(times int) IStream[T]
+
+	// Limit keeps only the first N elements of the resulting stream. If no sorts are staged (or the source is already
+	// sorted, see `AssumeSorted`), the processing of the stream stops as soon as N elements are found, so it can be used
+	// to limit lazy or infinite sources, such as the ones created with `Generate` or `FromChannel`.
+	Limit(n int) IStream[T]
+
+	// EveryNth keeps every Nth element of the resulting stream, at the positions 0, N, 2N, ..., useful to downsample
+	// large series. N <= 1 keeps all the elements.
+	EveryNth(n int) IStream[T]
+
+	// SkipEveryNth discards every Nth element of the resulting stream, at the positions 0, N, 2N, ..., keeping the
+	// elements that `EveryNth` discards. N <= 0 keeps all the elements.
+	SkipEveryNth(n int) IStream[T]
+
+	// SkipLast discards the last N elements of the resulting stream. The elements of lazy sources of unknown length,
+	// such as channels, are buffered in a window of N elements, where every element is released once N more elements
+	// are read after it.
+	SkipLast(n int) IStream[T]
+
+	// LimitLast keeps only the last N elements of the resulting stream. Lazy sources of unknown length, such as
+	// channels, are consumed entirely while buffering only the last N elements read.
+	LimitLast(n int) IStream[T]
+
+	// UnionWith produces the unique values contained by the resulting stream or the provided slice. The values of the
+	// stream are kept first, in order, followed by the values of the slice which were not already in the stream.
+	UnionWith(other []T) IStream[T]
+
+	// IntersectWith produces the unique values of the resulting stream which are also contained by the provided slice,
+	// in the order they are found in the stream.
+	IntersectWith(other []T) IStream[T]
+
+	// ExceptWith produces the unique values of the resulting stream which are not contained by the provided slice, in
+	// the order they are found in the stream.
+	ExceptWith(other []T) IStream[T]
+
+	// Append adds the provided items to the end of the resulting stream. The items are added after the filters and sorts
+	// are applied, so they are neither filtered nor sorted. For lazy sources, the items are produced once the source is
+	// exhausted.
+	Append(items ...T) IStream[T]
+
+	// Prepend adds the provided items to the beginning of the resulting stream. The items are added after the filters and
+	// sorts are applied, so they are neither filtered nor sorted.
+	Prepend(items ...T) IStream[T]
+
+	// Tap processes the stream and hands the resulting array to the provided function for inspection (such as logging),
+	// returning a new stream over the same elements so the operations can continue to be chained. Unlike the other
+	// intermediate operations, Tap processes the operations staged so far immediately.
+	Tap(f func([]T)) IStream[T]
+
+	// FirstN returns a list with up to the first `n` elements of the resulting stream. Stops as soon as `n` elements are
+	// found, so it is suitable for lazy or infinite sources as long as no sorts are staged.
+	FirstN(n int) IList[T]
+
+	// LastN returns a list with up to the last `n` elements of the resulting stream, keeping only `n` elements in memory
+	// while the stream is processed.
+	LastN(n int) IList[T]
+
 	// First Returns the first element of the resulting stream.
 	// Returns default T if the resulting stream is empty (or defaultValue if provided)
 	First(defaultValue ...T) T
@@ -158,6 +344,10 @@ type IStream[T comparable] interface {
 	// Returns default T if out of bounds (or defaultValue if provided)
 	At(index int, defaultValue ...T) T
 
+	// TryAt returns the element at the given index in the resulting stream, or an error wrapping ErrIndexOutOfRange if
+	// the index is out of bounds. Unlike `At`, a zero value element can be told apart from a missing one.
+	TryAt(index int) (T, error)
+
 	// AtReverse Returns the element at the given position, starting from the last element to the first in the resulting stream.
 	// Returns default T if out of bounds (or defaultValue if provided)
 	AtReverse(pos int, defaultValue ...T) T
@@ -165,6 +355,28 @@ type IStream[T comparable] interface {
 	// Count Counts the elements of the resulting stream
 	Count() int
 
+	// ReservoirSample selects `k` elements of the resulting stream uniformly at random, in a single pass and keeping only
+	// `k` elements in memory, which makes it suitable to sample lazy sources of unknown length. If the stream produces
+	// fewer than `k` elements, all of them are returned.
+	//
+	// - k:  The size of the sample.
+	// - r:  Optional source of randomness, useful for deterministic samples. If not provided, the default source of
+	//       the `math/rand` package is used.
+	ReservoirSample(k int, r ...*rand.Rand) IList[T]
+
+	// CountMatch counts the elements of the resulting stream that satisfy the provided condition, in a single pass and
+	// without staging a filter, so the stream can continue to be used without the condition applied.
+	CountMatch(f ConditionalFunc[T]) int
+
+	// CountDistinct counts the unique elements of the resulting stream, without producing the collection of the unique
+	// elements. See `CountDistinctBy` to count the unique keys of the elements instead.
+	CountDistinct() int
+
+	// CountAtLeast indicates whether the resulting stream contains at least `n` elements. Stops as soon as `n` elements
+	// are found instead of counting the entire stream, which makes it suitable for lazy or infinite sources as long as
+	// no sorts are staged.
+	CountAtLeast(n int) bool
+
 	// IsEmpty indicates whether the result of the stream produced no elements
 	IsEmpty() bool
 
@@ -173,11 +385,32 @@ type IStream[T comparable] interface {
 	// - value:   The value to be found.
 	Contains(value T) bool
 
+	// EqualTo processes this stream and the provided one, and indicates whether both produce the same elements in the
+	// same order.
+	//
+	// - other:   The stream to compare to.
+	EqualTo(other IStream[T]) bool
+
+	// EqualAsSet processes this stream and the provided one, and indicates whether both produce the same unique
+	// elements, regardless of their order or how many times they are repeated.
+	//
+	// - other:   The stream to compare to.
+	EqualAsSet(other IStream[T]) bool
+
 	// AnyMatch Indicates whether any elements of the stream match the given condition function.
 	//
 	// - f:       The matching function to be used.
 	AnyMatch(f ConditionalFunc[T]) bool
 
+	// AnyMatchParallel Indicates whether any elements of the stream match the given condition function, evaluating the
+	// condition concurrently by multiple go routines, useful for expensive conditions. Returns as soon as any go routine
+	// finds a match, without evaluating the remaining elements.
+	//
+	// - f:       The matching function to be used. Must be safe to be invoked concurrently.
+	// - threads: Indicates the amount of go routines to be used to a maximum of the available CPUs in the host machine. <= 0
+	//            indicates the maximum amount of available CPUs will be the number that determines the amount of go routines.
+	AnyMatchParallel(f ConditionalFunc[T], threads int) bool
+
 	// AllMatch Indicates whether ALL elements of the stream match the given condition function
 	//
 	// - f:       The matching function to be used.
@@ -218,6 +451,24 @@ type IStream[T comparable] interface {
 	// ForEach iterates over all elements in the stream calling the provided function.
 	ForEach(f IterFunc[T])
 
+	// ForEachUntil iterates over the elements in the stream calling the provided function until it returns `false`, at which
+	// point the iteration stops and no further elements are visited. If no sorts are staged and the stream is processed
+	// sequentially, the elements are read from the source only as they are needed.
+	ForEachUntil(f func(T) bool)
+
+	// ForEachErr iterates over the elements in the stream calling the provided function until it returns an error, at
+	// which point the iteration stops and the error is returned. Returns nil if all the elements were processed.
+	ForEachErr(f func(T) error) error
+
+	// WriteTo processes the stream and writes every element of the result to the provided writer, each one on its own
+	// line, without building the whole output in memory first. Stops at the first write error.
+	//
+	// - w:       The writer to write the elements to.
+	// - fmtFn:   Produces the string form of an element. If nil, the default format of `fmt.Sprint` is used.
+	//
+	// Returns the amount of bytes written and the error produced by the writer, if any.
+	WriteTo(w io.Writer, fmtFn func(T) string) (int64, error)
+
 	// ParallelForEach Iterates over all elements in the stream calling the provided function. Creates multiple go channels to parallelize
 	// the operation. ParallelForeach does not use any thread values previously provided in any filtering method nor enables parallel filtering
 	// if any filtering is done prior to the `ParallelForEach` phase. Only use `ParallelForEach` if the order in which the elements are processed
@@ -228,6 +479,34 @@ type IStream[T comparable] interface {
 	// - skipWait:  Indicates whether `ParallelForEach` will wait until all channels are done processing.
 	ParallelForEach(f IterFunc[T], threads int, skipWait ...bool)
 
+	// ParallelForEachAsync works as `ParallelForEach` without waiting for the go channels to finish processing, returning
+	// instead a channel which is closed once all the elements have been processed, so the completion can be awaited later.
+	//
+	// - threads:   Indicates the amount of go channels to be used to a maximum of the available CPUs in the host machine. <= 0 indicates
+	//              the maximum amount of available CPUs will be the number that determines the amount of go channels to be used.
+	ParallelForEachAsync(f IterFunc[T], threads int) <-chan struct{}
+
+	// ParallelBatch splits the resulting stream into batches of up to `size` elements and dispatches every batch to a pool
+	// of go routines calling the provided function, useful for parallel bulk operations such as batched inserts. Every
+	// batch is processed exactly once and the function returns once all the batches have been processed. The order in
+	// which the batches are processed is not guaranteed.
+	//
+	// - size:     The maximum amount of elements per batch. <= 0 dispatches all the elements in a single batch.
+	// - threads:  Indicates the amount of go routines to be used to a maximum of the available CPUs in the host machine. <= 0
+	//             indicates the maximum amount of available CPUs will be the number that determines the amount of go routines.
+	// - f:        The function that processes a batch.
+	ParallelBatch(size, threads int, f func([]T))
+
+	// ParallelForEachErr iterates over the elements in the stream calling the provided function concurrently by multiple
+	// go routines, returning the first error produced by the function. Once an error is produced, the remaining elements
+	// are not processed, although the elements being processed at the time are allowed to finish. The concurrent analog
+	// of `ForEachErr`.
+	//
+	// - f:        The function to be invoked for every element. Must be safe to be invoked concurrently.
+	// - threads:  Indicates the amount of go routines to be used to a maximum of the available CPUs in the host machine. <= 0
+	//             indicates the maximum amount of available CPUs will be the number that determines the amount of go routines.
+	ParallelForEachErr(f func(T) error, threads int) error
+
 	// ToArray Returns an array of elements from the resulting stream
 	ToArray() []T
 
@@ -237,11 +516,72 @@ type IStream[T comparable] interface {
 	// ToIterable returns a `IIterable` of elements from the resulting stream
 	ToIterable() IIterable[T]
 
+	// Iterator processes the stream and returns an iterator over the elements of the result, so custom iteration loops
+	// can be used over the resulting stream.
+	//
+	//	Usage:
+	//
+	//	    iterator := stream.Iterator()
+	//	    for x := iterator.Current(); iterator.HasNext(); x = iterator.Next() {
+	//	    }
+	Iterator() IIterator[T]
+
 	// ToList returns a `IList` of elements from the resulting stream
 	ToList() IList[T]
 
-	// ToDistinct processes the stream and outputs a set of unique values
+	// ToDistinct processes the stream and outputs a set of unique values. Unlike `Distinct`, the stream is not modified.
 	ToDistinct() ISet[T]
+
+	// ToSortedArray processes the stream and returns an array of the resulting elements sorted by the provided function,
+	// regardless of any sorts staged in the stream, which are only used to break the ties of the function.
+	//
+	// - desc:  indicates whether the sorting should be done descendant
+	ToSortedArray(cmp SortFunc[T], desc ...bool) []T
+
+	// ToSortedList processes the stream and returns a list of the resulting elements sorted by the provided function,
+	// regardless of any sorts staged in the stream, which are only used to break the ties of the function.
+	//
+	// - desc:  indicates whether the sorting should be done descendant
+	ToSortedList(cmp SortFunc[T], desc ...bool) IList[T]
+
+	// DistinctByHash processes the stream and returns the unique elements in the order they were first found, where the
+	// uniqueness is determined by the provided hash and equality functions instead of '==', useful for elements whose
+	// equality depends on their contents (such as pointers to structs) or is expensive to compare. Elements with the
+	// same hash are compared with the equality function.
+	DistinctByHash(hash func(T) uint64, eq func(a, b T) bool) IList[T]
+
+	// ToOrderedSet processes the stream and outputs the unique values in the order they were first found. The result
+	// behaves as a set, ignoring the addition of values it already contains, while also allowing access by index.
+	ToOrderedSet() IList[T]
+}
+
+// IStreamMapper binds the target type of a mapping operation for a stream, so the stream can be mapped without breaking
+// the fluent style of the stream operations. See `Into`.
+type IStreamMapper[From, To comparable] interface {
+	// Map maps the elements of the stream using the provided mapping function and returns a new stream with the mapped
+	// elements.
+	Map(f ConvertFunc[From, To]) IStream[To]
+}
+
+// IGroupedStream defines the operations over the elements of a stream grouped by key, created with `GroupBy`. The
+// groups are kept in the order their keys were first found.
+type IGroupedStream[K comparable, T comparable] interface {
+	// Keys returns the keys of the groups
+	Keys() []K
+
+	// Count counts the elements of every group
+	Count() IMap[K, int]
+
+	// Reduce reduces the elements of every group with the provided function, where the first element of every group is
+	// used as the initial value of the accumulation.
+	Reduce(f func(acc T, x T) T) IMap[K, T]
+
+	// ForEach iterates over the groups calling the provided function with the key of every group and a new stream of
+	// its elements, so further stream operations can be applied per group.
+	ForEach(f func(K, IStream[T]))
+
+	// ToMap returns a map of the elements of every group
+	ToMap() IMap[K, IList[T]]
 }
 
 // KeyValuePair is a structure which contains a pair of key-values from a map
@@ -250,6 +590,32 @@ type KeyValuePair[K comparable, V any] struct {
 	Value V
 }
 
+// Grouping is a node of the nested groupings produced by `GroupByMulti`. It contains the key of the group, the elements
+// that belong to it and, unless it is a group of the last level, the sub groups of its elements by the next key.
+type Grouping[T comparable, K comparable] struct {
+	Key    K
+	Items  IList[T]
+	Groups IMap[K, *Grouping[T, K]]
+}
+
+// Stats contains the summary statistics of a stream of numbers produced by `SummaryStats`. If the stream produced no
+// elements, all the statistics are zero.
+type Stats[T INumber] struct {
+	Count   int
+	Sum     T
+	Min     T
+	Max     T
+	Average float64
+}
+
+// Optional is a value which may or may not be present, useful for mapping functions that may not produce a result for
+// every element. Optionals are comparable, so they can be used as the elements of a stream. Use `OptionalOf` and
+// `EmptyOptional` to create them.
+type Optional[T comparable] struct {
+	value   T
+	present bool
+}
+
 // IMap defines the contract for a generic map which also represents a collection of `*KeyValuePairs`
 type IMap[K comparable, V any] interface {
 	IList[*KeyValuePair[K, V]]
@@ -263,12 +629,26 @@ type IMap[K comparable, V any] interface {
 	// Set is mapCollection specific function that allows a value to be added to the map without having to wrap it in a *KeyValuePair
 	Set(key K, value V) bool
 
+	// ComputeIfAbsent returns the value mapped to the provided key. If the key is not contained by the map, the value is
+	// produced by the provided function and stored in the map before being returned. The operation is atomic, so the
+	// function is invoked at most once per key even if the map is accessed concurrently.
+	ComputeIfAbsent(k K, f func(K) V) V
+
+	// Compute stores the value produced by the provided function for the given key, and returns it. The function
+	// receives the key, its current value and whether the key was contained by the map. The operation is atomic.
+	Compute(k K, f func(k K, current V, exists bool) V) V
+
 	// ContainsKey indicates whether the map contains the specified key
 	ContainsKey(k K) bool
 
 	// Keys returns the list of keys contained by the map
 	Keys() []K
 
+	// SortedKeys returns the list of keys contained by the map in sorted order. If a SortFunc is not provided, the keys are
+	// sorted by their natural order if they are of any of the ISortable kinds, otherwise they are returned in insertion
+	// order, same as `Keys`.
+	SortedKeys(cmp ...SortFunc[K]) []K
+
 	// Delete removes the item matching the specified key from the map. Returns false iff the key is not contained by the map
 	Delete(k K) bool
 